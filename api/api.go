@@ -0,0 +1,369 @@
+// Package api serves games and engine analysis as JSON over HTTP.
+//
+// Columns are 1-7 everywhere and move lists are move strings such as "4453".
+//
+//	POST   /games                 create a game: {"first": "x", "moves": "44"}
+//	GET    /games/{id}            current state of a game
+//	DELETE /games/{id}            forget a game
+//	POST   /games/{id}/moves      play a column: {"column": 4}
+//	POST   /games/{id}/engine     let the engine move: {"depth": 5, "timeMs": 500}
+//	POST   /analyze               search any position without storing it:
+//	                              {"moves": "4453"} or {"board": "<encoded>"},
+//	                              with optional depth and timeMs
+//
+// Errors come back as {"error": "..."} with a 4xx status.
+package api
+
+import (
+	"FinalProject/game"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultDepth = 5
+	MaxDepth     = 7
+)
+
+type Server struct {
+	// Deepest search a request may ask for
+	MaxDepth int
+
+	mu     sync.Mutex
+	games  map[string]*session
+	nextID int
+	mux    *http.ServeMux
+}
+
+// One stored game. Its own lock keeps a slow engine search from blocking
+// other games.
+type session struct {
+	mu    sync.Mutex
+	id    string
+	first int
+	moves []int
+	board *game.Board
+}
+
+func NewServer() *Server {
+	s := &Server{MaxDepth: MaxDepth, games: make(map[string]*session), mux: http.NewServeMux()}
+
+	s.mux.HandleFunc("POST /games", s.createGame)
+	s.mux.HandleFunc("GET /games/{id}", s.withGame(s.getGame))
+	s.mux.HandleFunc("DELETE /games/{id}", s.deleteGame)
+	s.mux.HandleFunc("POST /games/{id}/moves", s.withGame(s.playMove))
+	s.mux.HandleFunc("POST /games/{id}/engine", s.withGame(s.engineMove))
+	s.mux.HandleFunc("POST /analyze", s.analyze)
+	return s
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+type GameState struct {
+	ID         string   `json:"id"`
+	First      string   `json:"first"`
+	Moves      string   `json:"moves"`
+	Board      string   `json:"board"`
+	Rows       []string `json:"rows"` // top to bottom, '.' for empty
+	Turn       string   `json:"turn"`
+	ValidMoves []int    `json:"validMoves"`
+	Over       bool     `json:"over"`
+	Winner     string   `json:"winner,omitempty"`
+}
+
+type AnalysisResult struct {
+	Move   int    `json:"move,omitempty"`
+	Value  int    `json:"value"`
+	Static int    `json:"static"`
+	Line   string `json:"line"`
+	Depth  int    `json:"depth"`
+	TimeMs int64  `json:"timeMs"`
+}
+
+type EngineResult struct {
+	Analysis AnalysisResult `json:"analysis"`
+	Game     GameState      `json:"game"`
+}
+
+type createRequest struct {
+	First string `json:"first"`
+	Moves string `json:"moves"`
+}
+
+type moveRequest struct {
+	Column int `json:"column"`
+}
+
+type searchRequest struct {
+	Moves  string `json:"moves"`
+	Board  string `json:"board"`
+	Depth  int    `json:"depth"`
+	TimeMs int    `json:"timeMs"`
+}
+
+var errGameOver = errors.New("game is over")
+
+func (s *Server) createGame(w http.ResponseWriter, r *http.Request) {
+	var req createRequest
+	if !decode(w, r, &req) {
+		return
+	}
+
+	g := &session{board: game.NewBoard()}
+	switch req.First {
+	case "", "x", "X":
+	case "o", "O":
+		g.first = 1
+		g.board.WhoseTurn = 1
+	default:
+		writeError(w, http.StatusBadRequest, fmt.Errorf("unknown first player %q", req.First))
+		return
+	}
+
+	moves, err := game.ParseMoves(req.Moves)
+	if err == nil {
+		err = g.board.PlayMoves(moves)
+	}
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	g.moves = moves
+
+	s.mu.Lock()
+	s.nextID++
+	g.id = strconv.Itoa(s.nextID)
+	s.games[g.id] = g
+	s.mu.Unlock()
+
+	writeJSON(w, http.StatusCreated, g.state())
+}
+
+// Looks up the game named in the path and holds its lock for the handler
+func (s *Server) withGame(handler func(http.ResponseWriter, *http.Request, *session)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		g, ok := s.games[r.PathValue("id")]
+		s.mu.Unlock()
+
+		if !ok {
+			writeError(w, http.StatusNotFound, fmt.Errorf("no game %q", r.PathValue("id")))
+			return
+		}
+
+		g.mu.Lock()
+		defer g.mu.Unlock()
+		handler(w, r, g)
+	}
+}
+
+func (s *Server) getGame(w http.ResponseWriter, r *http.Request, g *session) {
+	writeJSON(w, http.StatusOK, g.state())
+}
+
+func (s *Server) deleteGame(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	_, ok := s.games[r.PathValue("id")]
+	delete(s.games, r.PathValue("id"))
+	s.mu.Unlock()
+
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Errorf("no game %q", r.PathValue("id")))
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) playMove(w http.ResponseWriter, r *http.Request, g *session) {
+	var req moveRequest
+	if !decode(w, r, &req) {
+		return
+	}
+
+	if err := g.play(req.Column - 1); err != nil {
+		status := http.StatusBadRequest
+		if err == errGameOver {
+			status = http.StatusConflict
+		}
+		writeError(w, status, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, g.state())
+}
+
+func (s *Server) engineMove(w http.ResponseWriter, r *http.Request, g *session) {
+	var req searchRequest
+	if !decode(w, r, &req) {
+		return
+	}
+
+	if g.board.CheckEndGame() {
+		writeError(w, http.StatusConflict, errGameOver)
+		return
+	}
+
+	result, err := s.search(g.board, req)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	g.play(result.Move - 1)
+	writeJSON(w, http.StatusOK, EngineResult{Analysis: result, Game: g.state()})
+}
+
+func (s *Server) analyze(w http.ResponseWriter, r *http.Request) {
+	var req searchRequest
+	if !decode(w, r, &req) {
+		return
+	}
+
+	board, err := requestBoard(req)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	result, err := s.search(board, req)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+// Builds the position to analyze from either a move string or a board
+func requestBoard(req searchRequest) (*game.Board, error) {
+	if req.Board != "" {
+		if req.Moves != "" {
+			return nil, errors.New("give either moves or board, not both")
+		}
+		return game.DecodeBoard(req.Board)
+	}
+
+	moves, err := game.ParseMoves(req.Moves)
+	if err != nil {
+		return nil, err
+	}
+
+	board := game.NewBoard()
+	if err := board.PlayMoves(moves); err != nil {
+		return nil, err
+	}
+	return board, nil
+}
+
+// Runs the search asked for. With a time limit the depth is the most that
+// will be searched; without one it is exactly what is searched.
+func (s *Server) search(board *game.Board, req searchRequest) (AnalysisResult, error) {
+	depth := req.Depth
+	if depth == 0 {
+		depth = DefaultDepth
+	}
+	if depth < 1 || depth > s.MaxDepth {
+		return AnalysisResult{}, fmt.Errorf("depth must be between 1 and %d", s.MaxDepth)
+	}
+	if req.TimeMs < 0 {
+		return AnalysisResult{}, errors.New("timeMs can't be negative")
+	}
+
+	start := time.Now()
+	var analysis game.Analysis
+	if req.TimeMs > 0 {
+		analysis = game.AnalyzeTimed(board, depth, time.Duration(req.TimeMs)*time.Millisecond)
+	} else {
+		analysis = game.Analyze(board, depth)
+	}
+
+	return AnalysisResult{
+		Move:   analysis.Move + 1,
+		Value:  analysis.Value,
+		Static: board.CalcPlayerValue(game.Tokens[board.WhoseTurn]),
+		Line:   game.FormatMoves(analysis.Line),
+		Depth:  analysis.Depth,
+		TimeMs: time.Since(start).Milliseconds(),
+	}, nil
+}
+
+func (g *session) play(col int) error {
+	if g.board.CheckEndGame() {
+		return errGameOver
+	}
+	if col < 0 || col >= game.NumCols || !g.board.IsValidMove(col) {
+		return fmt.Errorf("column %d is not a legal move", col+1)
+	}
+
+	g.board.MakeMove(col)
+	g.board.CheckEndGame()
+	g.moves = append(g.moves, col)
+	return nil
+}
+
+func (g *session) state() GameState {
+	return NewGameState(g.id, g.first, g.moves, g.board)
+}
+
+// Describes a board for JSON. id and first are only informational.
+func NewGameState(id string, first int, moves []int, board *game.Board) GameState {
+	state := GameState{
+		ID:         id,
+		First:      string(game.Tokens[first]),
+		Moves:      game.FormatMoves(moves),
+		Board:      board.Encode(),
+		Turn:       string(game.Tokens[board.WhoseTurn]),
+		ValidMoves: []int{},
+		Over:       board.CheckEndGame(),
+	}
+
+	for r := game.NumRows - 1; r >= 0; r-- {
+		row := make([]byte, game.NumCols)
+		for c := 0; c < game.NumCols; c++ {
+			row[c] = board.At(c, r)
+			if row[c] == ' ' {
+				row[c] = '.'
+			}
+		}
+		state.Rows = append(state.Rows, string(row))
+	}
+
+	if !state.Over {
+		for c := 0; c < game.NumCols; c++ {
+			if board.IsValidMove(c) {
+				state.ValidMoves = append(state.ValidMoves, c+1)
+			}
+		}
+	}
+	if board.Winner != ' ' {
+		state.Winner = string(board.Winner)
+	}
+	return state
+}
+
+// Reads a JSON body into v. An empty body leaves v as is.
+func decode(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil && err != io.EOF {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("bad request body: %v", err))
+		return false
+	}
+	return true
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, map[string]string{"error": err.Error()})
+}
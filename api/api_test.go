@@ -0,0 +1,127 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// Sends a request to the server and decodes the JSON reply into out
+func do(t *testing.T, s *Server, method string, path string, body string, out interface{}) int {
+	t.Helper()
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, req)
+
+	if out != nil && rec.Code < 300 {
+		if err := json.Unmarshal(rec.Body.Bytes(), out); err != nil {
+			t.Fatalf("%s %s: bad JSON %q: %v", method, path, rec.Body.String(), err)
+		}
+	}
+	return rec.Code
+}
+
+func TestCreateAndPlay(t *testing.T) {
+	s := NewServer()
+
+	var state GameState
+	if code := do(t, s, "POST", "/games", `{"moves": "44"}`, &state); code != http.StatusCreated {
+		t.Fatalf("Create returned %d", code)
+	}
+
+	if state.ID == "" || state.Moves != "44" || state.Turn != "X" {
+		t.Errorf("Unexpected new game: %+v", state)
+	}
+
+	if code := do(t, s, "POST", "/games/"+state.ID+"/moves", `{"column": 5}`, &state); code != http.StatusOK {
+		t.Fatalf("Move returned %d", code)
+	}
+
+	if state.Moves != "445" || state.Rows[5] != "...XX.." {
+		t.Errorf("Move wasn't applied: %+v", state)
+	}
+
+	var fetched GameState
+	do(t, s, "GET", "/games/"+state.ID, "", &fetched)
+	if fetched.Board != state.Board {
+		t.Error("GET should return the same board as the last move")
+	}
+}
+
+func TestIllegalMoves(t *testing.T) {
+	s := NewServer()
+
+	var state GameState
+	do(t, s, "POST", "/games", `{"moves": "111111"}`, &state)
+
+	if code := do(t, s, "POST", "/games/"+state.ID+"/moves", `{"column": 1}`, nil); code != http.StatusBadRequest {
+		t.Errorf("Move into a full column should be a bad request, got %d", code)
+	}
+
+	if code := do(t, s, "POST", "/games/"+state.ID+"/moves", `{"column": 9}`, nil); code != http.StatusBadRequest {
+		t.Errorf("Move off the board should be a bad request, got %d", code)
+	}
+
+	do(t, s, "POST", "/games", `{"moves": "1212121"}`, &state)
+	if !state.Over || state.Winner != "X" {
+		t.Errorf("Game should be won by X: %+v", state)
+	}
+
+	if code := do(t, s, "POST", "/games/"+state.ID+"/moves", `{"column": 3}`, nil); code != http.StatusConflict {
+		t.Errorf("Move after the end should conflict, got %d", code)
+	}
+
+	if code := do(t, s, "GET", "/games/nope", "", nil); code != http.StatusNotFound {
+		t.Errorf("Unknown game should be not found, got %d", code)
+	}
+}
+
+func TestEngineMove(t *testing.T) {
+	s := NewServer()
+
+	var state GameState
+	do(t, s, "POST", "/games", `{"moves": "121212"}`, &state)
+
+	var result EngineResult
+	if code := do(t, s, "POST", "/games/"+state.ID+"/engine", `{"depth": 2}`, &result); code != http.StatusOK {
+		t.Fatalf("Engine move returned %d", code)
+	}
+
+	if result.Analysis.Move != 1 || result.Game.Winner != "X" {
+		t.Errorf("Engine should have won in column 1: %+v", result)
+	}
+
+	if code := do(t, s, "POST", "/games/"+state.ID+"/engine", `{"depth": 99}`, nil); code != http.StatusConflict {
+		t.Errorf("Engine move after the end should conflict, got %d", code)
+	}
+}
+
+func TestAnalyze(t *testing.T) {
+	s := NewServer()
+
+	var result AnalysisResult
+	if code := do(t, s, "POST", "/analyze", `{"moves": "121212", "depth": 2}`, &result); code != http.StatusOK {
+		t.Fatalf("Analyze returned %d", code)
+	}
+
+	if result.Move != 1 || result.Line != "1" || result.Value <= 0 {
+		t.Errorf("Analysis should find the win: %+v", result)
+	}
+
+	board := "......./......./......./......./......./...X... O"
+	if code := do(t, s, "POST", "/analyze", `{"board": "`+board+`", "timeMs": 50}`, &result); code != http.StatusOK {
+		t.Fatalf("Analyze by board returned %d", code)
+	}
+
+	if result.Depth < 1 || len(result.Line) != result.Depth {
+		t.Errorf("Timed analysis should report its depth and line: %+v", result)
+	}
+
+	for _, body := range []string{`{"depth": 50}`, `{"moves": "9"}`, `{"moves": "1", "board": "x"}`, `{"colour": 1}`} {
+		if code := do(t, s, "POST", "/analyze", body, nil); code != http.StatusBadRequest {
+			t.Errorf("Analyze %s should be a bad request, got %d", body, code)
+		}
+	}
+}
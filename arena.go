@@ -0,0 +1,103 @@
+package main
+
+import (
+	"FinalProject/game"
+	"FinalProject/games"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Plays the search players against each other in any of the games, e.g.
+//
+//	arena -game tictactoe -x smart:9 -o random -games 100
+//	arena -game gomoku -size 9 -x smart:2 -o smart:1 -show
+func arenaCommand(args []string) {
+	flags := flag.NewFlagSet("arena", flag.ExitOnError)
+	name := flags.String("game", "tictactoe", "game to play: connect4, tictactoe or gomoku")
+	xSpec := flags.String("x", "smart:3", "first player: random or smart[:depth]")
+	oSpec := flags.String("o", "random", "second player: random or smart[:depth]")
+	numGames := flags.Int("games", 10, "games to play")
+	decay := flags.Float64("decay", 1, "leaf discount, squared each layer; 1 for none")
+	size := flags.Int("size", 15, "gomoku board size")
+	length := flags.Int("length", 5, "gomoku stones in a row to win")
+	show := flags.Bool("show", false, "print each final position")
+	flags.Parse(args)
+
+	var players [2]game.GamePlayer
+	for i, spec := range []string{*xSpec, *oSpec} {
+		player, err := newGamePlayer(spec, *decay)
+		exitIf(err)
+		players[i] = player
+	}
+
+	var wins [2]int
+	draws := 0
+	for i := 0; i < *numGames; i++ {
+		g, err := newGame(*name, *size, *length)
+		exitIf(err)
+
+		winner := game.PlayGame(g, players)
+		if winner < 0 {
+			draws++
+		} else {
+			wins[winner]++
+		}
+
+		if *show {
+			printGame(g)
+			fmt.Println()
+		}
+	}
+
+	fmt.Printf("%s: %c (%s) won %d, %c (%s) won %d, %d drawn\n", *name,
+		game.Tokens[0], *xSpec, wins[0], game.Tokens[1], *oSpec, wins[1], draws)
+}
+
+func newGame(name string, size, length int) (game.Game, error) {
+	switch name {
+	case "connect4":
+		return game.NewConnectFour(game.NewBoard()), nil
+	case "tictactoe":
+		return games.NewTicTacToe(), nil
+	case "gomoku":
+		if size < 1 || length < 1 || length > size {
+			return nil, fmt.Errorf("can't play %d in a row on a %dx%d board", length, size, size)
+		}
+		return games.NewGomoku(size, length), nil
+	}
+	return nil, fmt.Errorf("unknown game %q", name)
+}
+
+// Builds a player for any game from a spec: random or smart[:depth]
+func newGamePlayer(spec string, decay float64) (game.GamePlayer, error) {
+	kind, arg, _ := strings.Cut(spec, ":")
+
+	switch kind {
+	case "random":
+		return &game.RandomPlayer{}, nil
+	case "smart":
+		depth := 3
+		if arg != "" {
+			var err error
+			if depth, err = strconv.Atoi(arg); err != nil || depth < 1 {
+				return nil, fmt.Errorf("bad search depth in %q", spec)
+			}
+		}
+
+		weights := game.DefaultWeights()
+		weights.Decay = decay
+		return &game.SmartPlayer{NumLayers: depth, Weights: weights}, nil
+	}
+	return nil, fmt.Errorf("unknown player %q, expected random or smart[:depth]", spec)
+}
+
+func printGame(g game.Game) {
+	if c4, ok := g.(*game.ConnectFour); ok {
+		c4.Board.Print()
+		return
+	}
+	fmt.Fprint(os.Stdout, g)
+}
@@ -0,0 +1,180 @@
+// Package book stores opening moves so players don't have to search the
+// first few plies of every game.
+//
+// A book file has one position per line: the position as written by
+// game.Board.Encode, then the book moves as column:weight pairs with columns
+// numbered 1-7.
+//
+//	......./......./......./......./......./....... X 4:12 3:5 5:5
+//
+// Blank lines and lines starting with # are ignored. Each position and its
+// mirror image share an entry.
+package book
+
+import (
+	"FinalProject/game"
+	"bufio"
+	"fmt"
+	"io"
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type Move struct {
+	Column int // 0-indexed
+	Weight int
+}
+
+type Book struct {
+	positions map[string][]Move
+}
+
+func New() *Book {
+	return &Book{positions: make(map[string][]Move)}
+}
+
+// Number of positions in the book
+func (book *Book) Len() int {
+	return len(book.positions)
+}
+
+// Sets the moves for a position, replacing any already there
+func (book *Book) Add(board *game.Board, moves []Move) {
+	key, mirrored := canonical(board)
+	if mirrored {
+		moves = mirrorMoves(moves)
+	} else {
+		moves = append([]Move(nil), moves...)
+	}
+	sort.Slice(moves, func(i, j int) bool { return moves[i].Column < moves[j].Column })
+	book.positions[key] = moves
+}
+
+// Returns the moves for a position, or nil if it isn't in the book
+func (book *Book) Lookup(board *game.Board) []Move {
+	key, mirrored := canonical(board)
+	moves := book.positions[key]
+	if mirrored {
+		moves = mirrorMoves(moves)
+	}
+	return moves
+}
+
+// Picks one of the position's moves at random in proportion to the
+// weights. Returns -1 if the position isn't in the book.
+func (book *Book) Choose(board *game.Board) int {
+	moves := book.Lookup(board)
+
+	total := 0
+	for _, move := range moves {
+		if move.Weight > 0 && board.IsValidMove(move.Column) {
+			total += move.Weight
+		}
+	}
+	if total == 0 {
+		return -1
+	}
+
+	pick := rand.Intn(total)
+	for _, move := range moves {
+		if move.Weight <= 0 || !board.IsValidMove(move.Column) {
+			continue
+		}
+		if pick < move.Weight {
+			return move.Column
+		}
+		pick -= move.Weight
+	}
+	return -1
+}
+
+// The key for a board and its mirror image is whichever encoding sorts
+// first. Also returns whether that was the mirror.
+func canonical(board *game.Board) (string, bool) {
+	key := board.Encode()
+	cells, turn, _ := strings.Cut(key, " ")
+
+	rows := strings.Split(cells, "/")
+	for i, row := range rows {
+		b := []byte(row)
+		for l, r := 0, len(b)-1; l < r; l, r = l+1, r-1 {
+			b[l], b[r] = b[r], b[l]
+		}
+		rows[i] = string(b)
+	}
+	mirror := strings.Join(rows, "/") + " " + turn
+
+	if mirror < key {
+		return mirror, true
+	}
+	return key, false
+}
+
+func mirrorMoves(moves []Move) []Move {
+	if moves == nil {
+		return nil
+	}
+	mirrored := make([]Move, len(moves))
+	for i, move := range moves {
+		mirrored[i] = Move{Column: game.NumCols - 1 - move.Column, Weight: move.Weight}
+	}
+	return mirrored
+}
+
+func Load(r io.Reader) (*Book, error) {
+	book := New()
+	scanner := bufio.NewScanner(r)
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) < 3 {
+			return nil, fmt.Errorf("book: line %d: want a position and moves", lineNum)
+		}
+
+		board, err := game.DecodeBoard(fields[0] + " " + fields[1])
+		if err != nil {
+			return nil, fmt.Errorf("book: line %d: %v", lineNum, err)
+		}
+
+		var moves []Move
+		for _, field := range fields[2:] {
+			col, weight, ok := strings.Cut(field, ":")
+			c, err1 := strconv.Atoi(col)
+			w, err2 := strconv.Atoi(weight)
+			if !ok || err1 != nil || err2 != nil || c < 1 || c > game.NumCols || w < 0 {
+				return nil, fmt.Errorf("book: line %d: bad move %q", lineNum, field)
+			}
+			moves = append(moves, Move{Column: c - 1, Weight: w})
+		}
+		book.Add(board, moves)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return book, nil
+}
+
+// Writes the book sorted by position so files diff well
+func (book *Book) Save(w io.Writer) error {
+	keys := make([]string, 0, len(book.positions))
+	for key := range book.positions {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	bw := bufio.NewWriter(w)
+	for _, key := range keys {
+		bw.WriteString(key)
+		for _, move := range book.positions[key] {
+			fmt.Fprintf(bw, " %d:%d", move.Column+1, move.Weight)
+		}
+		bw.WriteByte('\n')
+	}
+	return bw.Flush()
+}
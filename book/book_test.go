@@ -0,0 +1,132 @@
+package book
+
+import (
+	"FinalProject/game"
+	"strings"
+	"testing"
+)
+
+func TestLoadSave(t *testing.T) {
+	text := `# Openings
+......./......./......./......./......./....... X 4:10 3:2 5:2
+......./......./......./......./......./...X... O 4:1
+`
+	book, err := Load(strings.NewReader(text))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if book.Len() != 2 {
+		t.Errorf("Expected 2 positions, got %d", book.Len())
+	}
+
+	moves := book.Lookup(game.NewBoard())
+	if len(moves) != 3 || moves[1] != (Move{Column: 3, Weight: 10}) {
+		t.Errorf("Unexpected moves for the start: %v", moves)
+	}
+
+	var sb strings.Builder
+	if err := book.Save(&sb); err != nil {
+		t.Fatal(err)
+	}
+	again, err := Load(strings.NewReader(sb.String()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again.Len() != 2 || len(again.Lookup(game.NewBoard())) != 3 {
+		t.Errorf("Book should survive a save and load:\n%s", sb.String())
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	bad := []string{
+		"......./......./......./......./......./....... X",
+		"......./......./......./......./......./....... X 8:1",
+		"......./......./......./......./......./....... X 4:-1",
+		"......./......./......./......./......./....... Z 4:1",
+	}
+
+	for _, text := range bad {
+		if _, err := Load(strings.NewReader(text)); err == nil {
+			t.Errorf("Should reject %q", text)
+		}
+	}
+}
+
+func TestMirror(t *testing.T) {
+	book := New()
+
+	board := game.NewBoard()
+	board.MakeMove(0)
+	book.Add(board, []Move{{Column: 1, Weight: 1}})
+
+	mirror := game.NewBoard()
+	mirror.MakeMove(6)
+	moves := book.Lookup(mirror)
+
+	if book.Len() != 1 || len(moves) != 1 || moves[0].Column != 5 {
+		t.Errorf("Mirror position should get the mirrored move, got %v", moves)
+	}
+}
+
+func TestChoose(t *testing.T) {
+	book := New()
+	board := game.NewBoard()
+	book.Add(board, []Move{{Column: 2, Weight: 0}, {Column: 3, Weight: 5}})
+
+	for i := 0; i < 20; i++ {
+		if move := book.Choose(board); move != 3 {
+			t.Fatalf("Should never choose a move with no weight, chose %d", move)
+		}
+	}
+
+	board.MakeMove(3)
+	if move := book.Choose(board); move != -1 {
+		t.Errorf("Position out of book should give -1, not %d", move)
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	book := Generate(1, 2, 0, nil)
+
+	// The start plus the 4 distinct first moves after mirroring, for each
+	// token moving first
+	if book.Len() != 10 {
+		t.Errorf("Expected 10 positions, got %d", book.Len())
+	}
+
+	moves := book.Lookup(game.NewBoard())
+	if len(moves) == 0 {
+		t.Fatal("Start position should be in the book")
+	}
+	for _, move := range moves {
+		if move.Weight != 1 {
+			t.Errorf("With no margin only the best moves are kept: %v", moves)
+		}
+	}
+}
+
+func TestGenerateOFirst(t *testing.T) {
+	book := Generate(2, 2, 0, nil)
+
+	board := game.NewBoard()
+	board.WhoseTurn = 1
+	if len(book.Lookup(board)) == 0 {
+		t.Error("Start with O to move should be in the book")
+	}
+
+	board.PlayMoves([]int{2, 3})
+	if move := book.Choose(board); move < 0 {
+		t.Errorf("O's opening should be in the book: %s", board.Encode())
+	}
+}
+
+func TestGenerateFindsWin(t *testing.T) {
+	board := game.NewBoard()
+	board.PlayMoves([]int{0, 1, 0, 1, 0, 1})
+
+	moves := searchMoves(board, 2, 10)
+	if len(moves) != 1 || moves[0].Column != 0 {
+		t.Errorf("Only the winning move should be kept, got %v", moves)
+	}
+}
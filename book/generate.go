@@ -0,0 +1,117 @@
+package book
+
+import (
+	"FinalProject/game"
+	"runtime"
+	"sync"
+)
+
+// Values past this are wins or losses, which are all alike to the book
+const valueCap = game.ReviewCap
+
+// Builds a book for every position reachable in plies moves or fewer by
+// searching each move in it to depth layers. Moves scoring within margin of
+// the best are kept, weighted by how close they come. progress, if not nil,
+// is called as positions finish.
+func Generate(plies int, depth int, margin int, progress func(done int, total int)) *Book {
+	positions := reachable(plies)
+
+	book := New()
+	var mu sync.Mutex
+	done := 0
+
+	jobs := make(chan *game.Board)
+	wg := &sync.WaitGroup{}
+	for i := 0; i < runtime.NumCPU(); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for board := range jobs {
+				moves := searchMoves(board, depth, margin)
+
+				mu.Lock()
+				book.Add(board, moves)
+				done++
+				if progress != nil {
+					progress(done, len(positions))
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+	for _, board := range positions {
+		jobs <- board
+	}
+	close(jobs)
+	wg.Wait()
+
+	return book
+}
+
+// Returns every unfinished position within plies moves of the start, one
+// per mirror pair. Either token may move first, as in simulations that
+// switch off.
+func reachable(plies int) []*game.Board {
+	seen := make(map[string]bool)
+	var positions []*game.Board
+
+	var layer []*game.Board
+	for first := range game.Tokens {
+		board := game.NewBoard()
+		board.WhoseTurn = first
+		layer = append(layer, board)
+	}
+	for ply := 0; ply <= plies; ply++ {
+		var next []*game.Board
+		for _, board := range layer {
+			key, _ := canonical(board)
+			if seen[key] || board.CheckEndGame() {
+				continue
+			}
+			seen[key] = true
+			positions = append(positions, board)
+
+			if ply == plies {
+				continue
+			}
+			for col := 0; col < game.NumCols; col++ {
+				if board.IsValidMove(col) {
+					child := board.DuplicateBoard()
+					child.MakeMove(col)
+					next = append(next, child)
+				}
+			}
+		}
+		layer = next
+	}
+	return positions
+}
+
+// Searches each legal move and keeps the ones within margin of the best
+func searchMoves(board *game.Board, depth int, margin int) []Move {
+	values := make(map[int]int)
+	best := -valueCap
+	for col := 0; col < game.NumCols; col++ {
+		if !board.IsValidMove(col) {
+			continue
+		}
+
+		child := board.DuplicateBoard()
+		child.MakeMove(col)
+
+		// The child's value is for the opponent
+		value := -game.Analyze(child, game.Max(depth-1, 1)).Value
+		value = game.Max(-valueCap, game.Min(valueCap, value))
+		values[col] = value
+		best = game.Max(best, value)
+	}
+
+	var moves []Move
+	for col := 0; col < game.NumCols; col++ {
+		if value, ok := values[col]; ok && best-value <= margin {
+			moves = append(moves, Move{Column: col, Weight: margin - (best - value) + 1})
+		}
+	}
+	return moves
+}
@@ -0,0 +1,49 @@
+package book
+
+import (
+	"FinalProject/game"
+	"io"
+)
+
+// Plays from the book while the game is in it and lets Inner move after
+type BookPlayer struct {
+	Book  *Book
+	Inner game.Player
+
+	// Whether the last move came from the book
+	LastFromBook bool
+}
+
+func NewBookPlayer(book *Book, inner game.Player) *BookPlayer {
+	return &BookPlayer{Book: book, Inner: inner}
+}
+
+func (player *BookPlayer) MakeMove(board *game.Board) int {
+	move := player.Book.Choose(board)
+	player.LastFromBook = move >= 0
+	if move < 0 {
+		return player.Inner.MakeMove(board)
+	}
+
+	board.MakeMove(move)
+	return move
+}
+
+// Evaluation of Inner's last move, or false if the book chose it
+func (player *BookPlayer) LastEvaluation() (int, bool) {
+	if player.LastFromBook {
+		return 0, false
+	}
+	if inner, ok := player.Inner.(game.Evaluating); ok {
+		return inner.LastEvaluation()
+	}
+	return 0, false
+}
+
+// Closes Inner if it needs closing
+func (player *BookPlayer) Close() error {
+	if closer, ok := player.Inner.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
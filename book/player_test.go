@@ -0,0 +1,38 @@
+package book
+
+import (
+	"FinalProject/game"
+	"testing"
+)
+
+type fixedPlayer struct {
+	move  int
+	moves int
+}
+
+func (player *fixedPlayer) MakeMove(board *game.Board) int {
+	player.moves++
+	board.MakeMove(player.move)
+	return player.move
+}
+
+func TestBookPlayer(t *testing.T) {
+	book := New()
+	book.Add(game.NewBoard(), []Move{{Column: 3, Weight: 1}})
+
+	inner := &fixedPlayer{move: 0}
+	player := NewBookPlayer(book, inner)
+
+	board := game.NewBoard()
+	if move := player.MakeMove(board); move != 3 || !player.LastFromBook || inner.moves != 0 {
+		t.Errorf("First move should come from the book, got %d", move)
+	}
+
+	if board.Height(3) != 1 {
+		t.Error("Book move should be made on the board")
+	}
+
+	if move := player.MakeMove(board); move != 0 || player.LastFromBook || inner.moves != 1 {
+		t.Errorf("Out of book the inner player should move, got %d", move)
+	}
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"FinalProject/book"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+)
+
+// Generates an opening book by searching every position up to some number
+// of plies
+func bookCommand(args []string) {
+	flags := flag.NewFlagSet("book", flag.ExitOnError)
+	plies := flags.Int("plies", 4, "cover every position up to this many moves in")
+	depth := flags.Int("depth", 6, "layers to search each position")
+	margin := flags.Int("margin", 5, "keep moves scoring within this much of the best")
+	out := flags.String("out", "opening.book", "file to write")
+	flags.Parse(args)
+
+	start := time.Now()
+	b := book.Generate(*plies, *depth, *margin, func(done int, total int) {
+		fmt.Printf("\rSearched %d/%d positions", done, total)
+	})
+	fmt.Println()
+
+	f, err := os.Create(*out)
+	exitIf(err)
+	if err := b.Save(f); err != nil {
+		f.Close()
+		exitIf(err)
+	}
+	exitIf(f.Close())
+	fmt.Printf("Wrote %d positions to %s in %v\n", b.Len(), *out, time.Since(start).Round(time.Millisecond))
+}
+
+func loadBook(path string) (*book.Book, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return book.Load(f)
+}
@@ -0,0 +1,367 @@
+// Package cfr solves two player zero-sum games of imperfect information by
+// counterfactual regret minimization.
+package cfr
+
+import "fmt"
+
+// A position in a game. Play returns a new state and leaves the old one as
+// it was.
+type State interface {
+	Terminal() bool
+
+	// Payoff to player 0 at a terminal state; player 1 gets minus it
+	Payoff() float64
+
+	// Whether chance moves next
+	Chance() bool
+
+	// Probability of each chance action
+	ChanceProbs() []float64
+
+	// The player to move, 0 or 1
+	Player() int
+
+	// Number of actions available, the same throughout an information set
+	Actions() int
+
+	// What the player to move knows. States the player can't tell apart
+	// share a key.
+	InfoSet() string
+
+	Play(action int) State
+}
+
+// The probability of each action at each information set
+type Strategy map[string][]float64
+
+// Looks up an information set, playing uniformly at sets never seen
+func (s Strategy) probs(key string, actions int) []float64 {
+	if probs, ok := s[key]; ok {
+		return probs
+	}
+	probs := make([]float64, actions)
+	for i := range probs {
+		probs[i] = 1 / float64(actions)
+	}
+	return probs
+}
+
+const (
+	terminal = -1
+	chance   = -2
+)
+
+// The game tree, built once so the solver doesn't replay moves
+type node struct {
+	player   int // 0, 1, terminal or chance
+	set      *infoSet
+	children []*node
+	probs    []float64 // chance only
+	payoff   float64   // terminal only
+}
+
+type infoSet struct {
+	key         string
+	player      int
+	regret      []float64
+	strategySum []float64
+
+	// Best response action, or -1 before it's worked out
+	best int
+}
+
+type Solver struct {
+	// Use CFR+: regrets floored at zero and later iterations weighted more
+	// in the average strategy
+	Plus bool
+
+	// Iterations run so far
+	Iterations int
+
+	root *node
+	sets map[string]*infoSet
+}
+
+// Builds the whole game tree from root
+func NewSolver(root State) (*Solver, error) {
+	s := &Solver{sets: make(map[string]*infoSet)}
+	var err error
+	s.root, err = s.build(root)
+	return s, err
+}
+
+func (s *Solver) build(state State) (*node, error) {
+	if state.Terminal() {
+		return &node{player: terminal, payoff: state.Payoff()}, nil
+	}
+
+	n := &node{player: state.Player()}
+	var actions int
+	if state.Chance() {
+		n.player = chance
+		n.probs = state.ChanceProbs()
+		actions = len(n.probs)
+	} else {
+		actions = state.Actions()
+		key := state.InfoSet()
+		set := s.sets[key]
+		if set == nil {
+			set = &infoSet{key: key, player: n.player, regret: make([]float64, actions), strategySum: make([]float64, actions)}
+			s.sets[key] = set
+		}
+		if len(set.regret) != actions || set.player != n.player {
+			return nil, fmt.Errorf("cfr: states in information set %q differ in player or actions", key)
+		}
+		n.set = set
+	}
+
+	for a := 0; a < actions; a++ {
+		child, err := s.build(state.Play(a))
+		if err != nil {
+			return nil, err
+		}
+		n.children = append(n.children, child)
+	}
+	return n, nil
+}
+
+// Number of information sets in the game
+func (s *Solver) InfoSets() int {
+	return len(s.sets)
+}
+
+// Runs more iterations, each updating player 0 then player 1
+func (s *Solver) Run(iterations int) {
+	for i := 0; i < iterations; i++ {
+		s.Iterations++
+		for player := 0; player < 2; player++ {
+			s.walk(s.root, player, [2]float64{1, 1}, 1)
+		}
+	}
+}
+
+// Regret matching: play actions in proportion to their positive regret
+func (set *infoSet) current() []float64 {
+	probs := make([]float64, len(set.regret))
+	total := 0.0
+	for a, r := range set.regret {
+		if r > 0 {
+			probs[a] = r
+			total += r
+		}
+	}
+	for a := range probs {
+		if total > 0 {
+			probs[a] /= total
+		} else {
+			probs[a] = 1 / float64(len(probs))
+		}
+	}
+	return probs
+}
+
+// Returns the value of n for traverser, updating traverser's regrets.
+// reach holds each player's probability of playing to n and chanceReach
+// chance's.
+func (s *Solver) walk(n *node, traverser int, reach [2]float64, chanceReach float64) float64 {
+	switch n.player {
+	case terminal:
+		if traverser == 0 {
+			return n.payoff
+		}
+		return -n.payoff
+	case chance:
+		value := 0.0
+		for a, child := range n.children {
+			value += n.probs[a] * s.walk(child, traverser, reach, chanceReach*n.probs[a])
+		}
+		return value
+	}
+
+	probs := n.set.current()
+	if n.player != traverser {
+		value := 0.0
+		for a, child := range n.children {
+			if probs[a] == 0 {
+				continue
+			}
+			next := reach
+			next[n.player] *= probs[a]
+			value += probs[a] * s.walk(child, traverser, next, chanceReach)
+		}
+		return value
+	}
+
+	values := make([]float64, len(n.children))
+	value := 0.0
+	for a, child := range n.children {
+		next := reach
+		next[n.player] *= probs[a]
+		values[a] = s.walk(child, traverser, next, chanceReach)
+		value += probs[a] * values[a]
+	}
+
+	// Counterfactual: as if the traverser played to get here
+	others := reach[1-traverser] * chanceReach
+	weight := reach[traverser]
+	if s.Plus {
+		weight *= float64(s.Iterations)
+	}
+	for a := range values {
+		n.set.regret[a] += others * (values[a] - value)
+		if s.Plus && n.set.regret[a] < 0 {
+			n.set.regret[a] = 0
+		}
+		n.set.strategySum[a] += weight * probs[a]
+	}
+	return value
+}
+
+// The average strategy, which converges to a Nash equilibrium
+func (s *Solver) Average() Strategy {
+	strategy := make(Strategy, len(s.sets))
+	for key, set := range s.sets {
+		probs := make([]float64, len(set.strategySum))
+		total := 0.0
+		for _, v := range set.strategySum {
+			total += v
+		}
+		for a, v := range set.strategySum {
+			if total > 0 {
+				probs[a] = v / total
+			} else {
+				probs[a] = 1 / float64(len(probs))
+			}
+		}
+		strategy[key] = probs
+	}
+	return strategy
+}
+
+// Expected payoff to player 0 when both players follow strategy
+func (s *Solver) Value(strategy Strategy) float64 {
+	return s.value(s.root, strategy)
+}
+
+func (s *Solver) value(n *node, strategy Strategy) float64 {
+	if n.player == terminal {
+		return n.payoff
+	}
+
+	probs := n.probs
+	if n.player != chance {
+		probs = strategy.probs(n.set.key, len(n.children))
+	}
+	value := 0.0
+	for a, child := range n.children {
+		if probs[a] > 0 {
+			value += probs[a] * s.value(child, strategy)
+		}
+	}
+	return value
+}
+
+// How much a player could gain on average by switching to a best response
+// against strategy: half the sum of both best response values. Zero at a
+// Nash equilibrium.
+func (s *Solver) Exploitability(strategy Strategy) float64 {
+	return (s.BestResponse(0, strategy) + s.BestResponse(1, strategy)) / 2
+}
+
+// Value to player of the best response to the other player's part of
+// strategy
+func (s *Solver) BestResponse(player int, strategy Strategy) float64 {
+	// Each of player's sets gathers its states with the chance and opponent
+	// probability of reaching them
+	states := make(map[*infoSet][]reached)
+	s.gather(s.root, player, strategy, 1, states)
+
+	for _, set := range s.sets {
+		set.best = -1
+	}
+	values := make(map[*node]float64)
+	value := s.responseValue(s.root, player, strategy, states, values)
+	if player == 1 {
+		return -value
+	}
+	return value
+}
+
+type reached struct {
+	n     *node
+	reach float64
+}
+
+func (s *Solver) gather(n *node, player int, strategy Strategy, reach float64, states map[*infoSet][]reached) {
+	if n.player == terminal || reach == 0 {
+		return
+	}
+
+	probs := n.probs
+	switch n.player {
+	case player:
+		states[n.set] = append(states[n.set], reached{n, reach})
+		probs = nil
+	case 1 - player:
+		probs = strategy.probs(n.set.key, len(n.children))
+	}
+	for a, child := range n.children {
+		if probs == nil {
+			s.gather(child, player, strategy, reach, states)
+		} else {
+			s.gather(child, player, strategy, reach*probs[a], states)
+		}
+	}
+}
+
+// Payoff to player 0 below n when player best responds
+func (s *Solver) responseValue(n *node, player int, strategy Strategy, states map[*infoSet][]reached, values map[*node]float64) float64 {
+	if value, ok := values[n]; ok {
+		return value
+	}
+
+	value := 0.0
+	switch n.player {
+	case terminal:
+		value = n.payoff
+	case player:
+		if n.set.best < 0 {
+			n.set.best = s.bestAction(n.set, player, strategy, states, values)
+		}
+		value = s.responseValue(n.children[n.set.best], player, strategy, states, values)
+	default:
+		probs := n.probs
+		if n.player != chance {
+			probs = strategy.probs(n.set.key, len(n.children))
+		}
+		for a, child := range n.children {
+			if probs[a] > 0 {
+				value += probs[a] * s.responseValue(child, player, strategy, states, values)
+			}
+		}
+	}
+
+	values[n] = value
+	return value
+}
+
+// The action with the highest value to player summed over the set's states
+// weighted by how likely each is
+func (s *Solver) bestAction(set *infoSet, player int, strategy Strategy, states map[*infoSet][]reached, values map[*node]float64) int {
+	sign := 1.0
+	if player == 1 {
+		sign = -1
+	}
+
+	best, bestValue := 0, 0.0
+	for a := range set.regret {
+		total := 0.0
+		for _, r := range states[set] {
+			total += r.reach * sign * s.responseValue(r.n.children[a], player, strategy, states, values)
+		}
+		if a == 0 || total > bestValue {
+			best, bestValue = a, total
+		}
+	}
+	return best
+}
@@ -0,0 +1,80 @@
+package cfr
+
+import (
+	"math"
+	"testing"
+)
+
+func solve(t *testing.T, root State, plus bool, iterations int) (*Solver, Strategy) {
+	t.Helper()
+	s, err := NewSolver(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.Plus = plus
+	s.Run(iterations)
+	return s, s.Average()
+}
+
+func TestKuhn(t *testing.T) {
+	for _, plus := range []bool{false, true} {
+		s, strategy := solve(t, NewKuhn(), plus, 3000)
+		if s.InfoSets() != 12 {
+			t.Fatalf("Kuhn poker has 12 information sets, got %d", s.InfoSets())
+		}
+
+		if e := s.Exploitability(strategy); e > 0.005 || e < -1e-9 {
+			t.Errorf("Plus %v: should be close to equilibrium, exploitability %v", plus, e)
+		}
+		if v := s.Value(strategy); math.Abs(v+1.0/18) > 0.005 {
+			t.Errorf("Plus %v: first player should expect -1/18, got %v", plus, v)
+		}
+
+		// Never bet a queen first, always call with a king
+		if strategy["Q:"][1] > 0.05 || strategy["K:b"][1] < 0.95 {
+			t.Errorf("Plus %v: should play the known equilibrium, got Q: %v K:b %v", plus, strategy["Q:"], strategy["K:b"])
+		}
+	}
+}
+
+func TestPlusConvergesFaster(t *testing.T) {
+	vanilla, vanillaStrategy := solve(t, NewKuhn(), false, 200)
+	plus, plusStrategy := solve(t, NewKuhn(), true, 200)
+	if plus.Exploitability(plusStrategy) >= vanilla.Exploitability(vanillaStrategy) {
+		t.Errorf("CFR+ should be less exploitable after 200 iterations: %v vs %v",
+			plus.Exploitability(plusStrategy), vanilla.Exploitability(vanillaStrategy))
+	}
+}
+
+func TestBestResponse(t *testing.T) {
+	s, _ := solve(t, NewKuhn(), false, 0)
+
+	// Against a player who always passes, betting wins the ante
+	passive := make(Strategy)
+	for _, card := range []string{"J", "Q", "K"} {
+		for _, history := range []string{"", "p", "b", "pb"} {
+			passive[card+":"+history] = []float64{1, 0}
+		}
+	}
+	if v := s.BestResponse(1, passive); math.Abs(v-1) > 1e-9 {
+		t.Errorf("Should bet into a passive first player for 1, got %v", v)
+	}
+}
+
+// Different actions in one information set can't be solved
+type badState struct{ depth int }
+
+func (b badState) Terminal() bool         { return b.depth == 2 }
+func (b badState) Payoff() float64        { return 0 }
+func (b badState) Chance() bool           { return false }
+func (b badState) ChanceProbs() []float64 { return nil }
+func (b badState) Player() int            { return b.depth }
+func (b badState) Actions() int           { return 2 - b.depth }
+func (b badState) InfoSet() string        { return "same" }
+func (b badState) Play(action int) State  { return badState{b.depth + 1} }
+
+func TestInconsistentInfoSets(t *testing.T) {
+	if _, err := NewSolver(badState{}); err == nil {
+		t.Error("Should reject an information set with two action counts")
+	}
+}
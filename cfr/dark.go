@@ -0,0 +1,136 @@
+package cfr
+
+import "fmt"
+
+// Connect Four where neither player sees the other's tokens. A player
+// learns only where their own tokens land, so the height of a column gives
+// away how many of the opponent's are under it. Choosing a column that has
+// filled up without the player knowing reveals that, and they choose again.
+type DarkConnectFour struct {
+	Width, Height int
+	Connect       int // tokens in a row to win
+
+	cells   []int8 // col*Height+row, -1 when empty
+	heights []int
+	turn    int
+	placed  int
+	winner  int
+
+	// What each player has seen: a digit for the column of each attempt
+	// then one for the row the token landed in, or F if the column was
+	// full
+	views [2]string
+}
+
+// An empty board up to 9 by 9. Small ones, such as 3x3 with three in a
+// row, are within reach of the solver.
+func NewDarkConnectFour(width, height, connect int) *DarkConnectFour {
+	d := &DarkConnectFour{
+		Width:   width,
+		Height:  height,
+		Connect: connect,
+		cells:   make([]int8, width*height),
+		heights: make([]int, width),
+		winner:  -1,
+	}
+	for i := range d.cells {
+		d.cells[i] = -1
+	}
+	return d
+}
+
+func (d *DarkConnectFour) Terminal() bool {
+	return d.winner >= 0 || d.placed == len(d.cells)
+}
+
+func (d *DarkConnectFour) Payoff() float64 {
+	switch d.winner {
+	case 0:
+		return 1
+	case 1:
+		return -1
+	}
+	return 0
+}
+
+func (d *DarkConnectFour) Chance() bool {
+	return false
+}
+
+func (d *DarkConnectFour) ChanceProbs() []float64 {
+	return nil
+}
+
+func (d *DarkConnectFour) Player() int {
+	return d.turn
+}
+
+// The columns the player to move doesn't know to be full
+func (d *DarkConnectFour) columns() []int {
+	full := make([]bool, d.Width)
+	view := d.views[d.turn]
+	for i := 0; i+1 < len(view); i += 2 {
+		if view[i+1] == 'F' || int(view[i+1]-'0') == d.Height-1 {
+			full[view[i]-'0'] = true
+		}
+	}
+
+	var cols []int
+	for col, known := range full {
+		if !known {
+			cols = append(cols, col)
+		}
+	}
+	return cols
+}
+
+func (d *DarkConnectFour) Actions() int {
+	return len(d.columns())
+}
+
+func (d *DarkConnectFour) InfoSet() string {
+	return fmt.Sprintf("%d:%s", d.turn, d.views[d.turn])
+}
+
+func (d *DarkConnectFour) Play(action int) State {
+	col := d.columns()[action]
+
+	next := *d
+	next.cells = append([]int8(nil), d.cells...)
+	next.heights = append([]int(nil), d.heights...)
+
+	row := d.heights[col]
+	if row == d.Height {
+		next.views[d.turn] += fmt.Sprintf("%dF", col)
+		return &next
+	}
+
+	next.cells[col*d.Height+row] = int8(d.turn)
+	next.heights[col]++
+	next.placed++
+	next.views[d.turn] += fmt.Sprintf("%d%d", col, row)
+	if next.wins(col, row) {
+		next.winner = d.turn
+	}
+	next.turn = 1 - d.turn
+	return &next
+}
+
+// Whether the token at col, row completes a line
+func (d *DarkConnectFour) wins(col, row int) bool {
+	token := d.cells[col*d.Height+row]
+	for _, dir := range [4][2]int{{1, 0}, {0, 1}, {1, 1}, {1, -1}} {
+		n := 1
+		for _, sign := range [2]int{1, -1} {
+			c, r := col+sign*dir[0], row+sign*dir[1]
+			for c >= 0 && c < d.Width && r >= 0 && r < d.Height && d.cells[c*d.Height+r] == token {
+				n++
+				c, r = c+sign*dir[0], r+sign*dir[1]
+			}
+		}
+		if n >= d.Connect {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,45 @@
+package cfr
+
+import "testing"
+
+func TestDarkRules(t *testing.T) {
+	var state State = NewDarkConnectFour(3, 2, 3)
+
+	state = state.Play(0) // X at 0,0
+	state = state.Play(0) // O at 0,1, so O knows column 0 is full
+	if state.InfoSet() != "0:00" || state.Actions() != 3 {
+		t.Fatalf("X should know only its own token: %q with %d actions", state.InfoSet(), state.Actions())
+	}
+
+	state = state.Play(0) // full, X finds out and chooses again
+	if state.Player() != 0 || state.InfoSet() != "0:000F" || state.Actions() != 2 {
+		t.Fatalf("X should learn column 0 is full: %q with %d actions", state.InfoSet(), state.Actions())
+	}
+
+	state = state.Play(0) // X at 1,0
+	if state.InfoSet() != "1:01" || state.Actions() != 2 {
+		t.Fatalf("O should skip the column it filled: %q with %d actions", state.InfoSet(), state.Actions())
+	}
+
+	state = state.Play(0) // O at 1,1
+	state = state.Play(1) // X at 2,0 for three along the bottom
+	if !state.Terminal() || state.Payoff() != 1 {
+		t.Errorf("X should have won along the bottom")
+	}
+}
+
+func TestDarkSolve(t *testing.T) {
+	s, err := NewSolver(NewDarkConnectFour(3, 3, 3))
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.Plus = true
+
+	s.Run(10)
+	early := s.Exploitability(s.Average())
+	s.Run(190)
+	late := s.Exploitability(s.Average())
+	if late >= early || late > 0.1 {
+		t.Errorf("Should get less exploitable, went from %v to %v", early, late)
+	}
+}
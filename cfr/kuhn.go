@@ -0,0 +1,81 @@
+package cfr
+
+// Kuhn poker: three cards, one each, an ante of 1 and a single bet of 1.
+// Actions are 0 to pass or fold and 1 to bet or call. The first player's
+// equilibrium value is -1/18.
+type Kuhn struct {
+	cards   [2]int // 0 jack, 1 queen, 2 king
+	dealt   bool
+	history string // p for pass, b for bet
+}
+
+// Kuhn poker before the deal
+func NewKuhn() *Kuhn {
+	return &Kuhn{}
+}
+
+// The six ways to deal two of the three cards
+var kuhnDeals = [6][2]int{{0, 1}, {0, 2}, {1, 0}, {1, 2}, {2, 0}, {2, 1}}
+
+func (k *Kuhn) Terminal() bool {
+	switch k.history {
+	case "pp", "bp", "bb", "pbp", "pbb":
+		return true
+	}
+	return false
+}
+
+func (k *Kuhn) Payoff() float64 {
+	// A fold gives up the ante to whoever bet
+	switch k.history {
+	case "bp":
+		return 1
+	case "pbp":
+		return -1
+	}
+
+	stake := 1.0
+	if k.history != "pp" {
+		stake = 2
+	}
+	if k.cards[0] > k.cards[1] {
+		return stake
+	}
+	return -stake
+}
+
+func (k *Kuhn) Chance() bool {
+	return !k.dealt
+}
+
+func (k *Kuhn) ChanceProbs() []float64 {
+	probs := make([]float64, len(kuhnDeals))
+	for i := range probs {
+		probs[i] = 1 / float64(len(kuhnDeals))
+	}
+	return probs
+}
+
+func (k *Kuhn) Player() int {
+	return len(k.history) % 2
+}
+
+func (k *Kuhn) Actions() int {
+	return 2
+}
+
+// The player's card and the betting so far, such as "K:pb"
+func (k *Kuhn) InfoSet() string {
+	return string("JQK"[k.cards[k.Player()]]) + ":" + k.history
+}
+
+func (k *Kuhn) Play(action int) State {
+	next := *k
+	if !k.dealt {
+		next.cards = kuhnDeals[action]
+		next.dealt = true
+		return &next
+	}
+	next.history += string("pb"[action])
+	return &next
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"FinalProject/cfr"
+	"flag"
+	"fmt"
+	"sort"
+)
+
+// Solves Kuhn poker or a small dark Connect Four by counterfactual regret
+// minimization, reporting exploitability as it goes
+func cfrCommand(args []string) {
+	flags := flag.NewFlagSet("cfr", flag.ExitOnError)
+	name := flags.String("game", "kuhn", "game to solve: kuhn or dark")
+	size := flags.String("size", "3x3", "dark board size as columns x rows, up to 9x9")
+	connect := flags.Int("connect", 3, "tokens in a row to win dark Connect Four")
+	iterations := flags.Int("iterations", 1000, "iterations to run")
+	every := flags.Int("every", 100, "report exploitability every this many iterations")
+	plus := flags.Bool("plus", true, "use CFR+ rather than vanilla CFR")
+	show := flags.Bool("show", false, "print the average strategy")
+	flags.Parse(args)
+
+	var root cfr.State
+	switch *name {
+	case "kuhn":
+		root = cfr.NewKuhn()
+	case "dark":
+		var width, height int
+		if _, err := fmt.Sscanf(*size, "%dx%d", &width, &height); err != nil || width < 1 || width > 9 || height < 1 || height > 9 {
+			exitIf(fmt.Errorf("bad size %q", *size))
+		}
+		root = cfr.NewDarkConnectFour(width, height, *connect)
+	default:
+		exitIf(fmt.Errorf("unknown game %q", *name))
+	}
+
+	solver, err := cfr.NewSolver(root)
+	exitIf(err)
+	solver.Plus = *plus
+	fmt.Printf("%d information sets\n", solver.InfoSets())
+
+	if *every < 1 {
+		*every = *iterations
+	}
+	for solver.Iterations < *iterations {
+		solver.Run(min(*every, *iterations-solver.Iterations))
+		strategy := solver.Average()
+		fmt.Printf("%8d iterations  exploitability %.6f  value %+.6f\n", solver.Iterations, solver.Exploitability(strategy), solver.Value(strategy))
+	}
+
+	if *show {
+		strategy := solver.Average()
+		keys := make([]string, 0, len(strategy))
+		for key := range strategy {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			fmt.Printf("%-12s", key)
+			for _, p := range strategy[key] {
+				fmt.Printf(" %.3f", p)
+			}
+			fmt.Println()
+		}
+	}
+}
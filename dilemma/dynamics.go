@@ -0,0 +1,160 @@
+package dilemma
+
+import (
+	"encoding/csv"
+	"io"
+	"math/rand"
+	"strconv"
+)
+
+// Replicator dynamics in the style of Axelrod's ecological tournament.
+// Each generation every strategy's share of the population grows in
+// proportion to its mean score against the current population. Returns
+// the shares after each generation, starting with the initial ones; nil
+// shares start everyone equal.
+func (r *Results) Ecological(shares []float64, generations int) [][]float64 {
+	n := len(r.Names)
+	if shares == nil {
+		shares = make([]float64, n)
+		for i := range shares {
+			shares[i] = 1 / float64(n)
+		}
+	}
+
+	history := [][]float64{shares}
+	for gen := 0; gen < generations; gen++ {
+		next := make([]float64, n)
+		total := 0.0
+		for i := range next {
+			fitness := 0.0
+			for j, share := range shares {
+				fitness += share * r.Scores[i][j]
+			}
+			next[i] = shares[i] * fitness
+			total += next[i]
+		}
+		if total == 0 {
+			break
+		}
+		for i := range next {
+			next[i] /= total
+		}
+
+		shares = next
+		history = append(history, shares)
+	}
+	return history
+}
+
+// A finite population evolving by the Moran process: each step an
+// individual chosen in proportion to its payoff has an offspring that
+// replaces one chosen at random. Offspring mutate to a random strategy with
+// probability Mutation.
+type Moran struct {
+	Size     int
+	Mutation float64
+
+	// Steps per reported generation; Size if zero
+	Steps int
+
+	Rand *rand.Rand
+}
+
+// Runs the population from equal numbers of each strategy and returns the
+// counts after each generation, starting with the initial ones
+func (m *Moran) Evolve(r *Results, generations int) [][]int {
+	n := len(r.Names)
+	counts := make([]int, n)
+	for i := 0; i < m.Size; i++ {
+		counts[i%n]++
+	}
+	steps := m.Steps
+	if steps == 0 {
+		steps = m.Size
+	}
+
+	history := [][]int{append([]int(nil), counts...)}
+	for gen := 0; gen < generations; gen++ {
+		for step := 0; step < steps; step++ {
+			m.step(r, counts)
+		}
+		history = append(history, append([]int(nil), counts...))
+	}
+	return history
+}
+
+func (m *Moran) step(r *Results, counts []int) {
+	// An individual's payoff is its mean score against everyone else
+	fitness := make([]float64, len(counts))
+	total := 0.0
+	for i, count := range counts {
+		if count == 0 {
+			continue
+		}
+		for j, other := range counts {
+			if i == j {
+				other--
+			}
+			fitness[i] += float64(other) * r.Scores[i][j]
+		}
+		fitness[i] /= float64(m.Size - 1)
+		total += fitness[i] * float64(count)
+	}
+
+	parent := pick(m.Rand, len(counts), func(i int) float64 {
+		if total == 0 {
+			return float64(counts[i])
+		}
+		return fitness[i] * float64(counts[i])
+	})
+	if m.Rand.Float64() < m.Mutation {
+		parent = m.Rand.Intn(len(counts))
+	}
+	dies := pick(m.Rand, len(counts), func(i int) float64 { return float64(counts[i]) })
+
+	counts[dies]--
+	counts[parent]++
+}
+
+// Index chosen with probability proportional to weight
+func pick(rng *rand.Rand, n int, weight func(int) float64) int {
+	total := 0.0
+	for i := 0; i < n; i++ {
+		total += weight(i)
+	}
+
+	x := rng.Float64() * total
+	for i := 0; i < n; i++ {
+		if x -= weight(i); x < 0 {
+			return i
+		}
+	}
+	return n - 1
+}
+
+// Writes a row per generation with a column per strategy
+func WriteDynamicsCSV(w io.Writer, names []string, history [][]float64) error {
+	cw := csv.NewWriter(w)
+	cw.Write(append([]string{"generation"}, names...))
+	for gen, shares := range history {
+		row := []string{strconv.Itoa(gen)}
+		for _, share := range shares {
+			row = append(row, strconv.FormatFloat(share, 'f', 6, 64))
+		}
+		cw.Write(row)
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// Counts as shares of a population of size
+func Shares(history [][]int, size int) [][]float64 {
+	shares := make([][]float64, len(history))
+	for gen, counts := range history {
+		shares[gen] = make([]float64, len(counts))
+		for i, count := range counts {
+			shares[gen][i] = float64(count) / float64(size)
+		}
+	}
+	return shares
+}
@@ -0,0 +1,54 @@
+package dilemma
+
+import (
+	"bytes"
+	"math"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+func TestEcological(t *testing.T) {
+	results := NewTournament([]Strategy{TitForTat{}, AlwaysDefect{}, AlwaysCooperate{}}).Run()
+	history := results.Ecological(nil, 200)
+
+	final := history[len(history)-1]
+	if len(history) != 201 || final[1] > 0.01 {
+		t.Errorf("Defectors should die out once their prey is gone, got %v", final)
+	}
+	sum := 0.0
+	for _, share := range final {
+		sum += share
+	}
+	if math.Abs(sum-1) > 1e-9 {
+		t.Errorf("Shares should add up to one, got %v", sum)
+	}
+
+	// Early on the defectors feed on the cooperators
+	if history[1][1] <= history[0][1] {
+		t.Errorf("Defectors should grow at first, got %v then %v", history[0], history[1])
+	}
+}
+
+func TestMoran(t *testing.T) {
+	results := NewTournament([]Strategy{AlwaysDefect{}, AlwaysCooperate{}}).Run()
+	moran := &Moran{Size: 20, Rand: rand.New(rand.NewSource(1))}
+	history := moran.Evolve(results, 50)
+
+	for _, counts := range history {
+		if counts[0]+counts[1] != 20 {
+			t.Fatalf("Should keep the population size, got %v", counts)
+		}
+	}
+	if final := history[len(history)-1]; final[0] != 20 {
+		t.Errorf("Defectors should take over, got %v", final)
+	}
+
+	var buf bytes.Buffer
+	if err := WriteDynamicsCSV(&buf, results.Names, Shares(history, 20)); err != nil {
+		t.Fatal(err)
+	}
+	if lines := strings.Split(strings.TrimSpace(buf.String()), "\n"); len(lines) != 52 || lines[0] != "generation,alld,allc" {
+		t.Errorf("Should write a header and a row per generation, got %d lines starting %q", len(lines), lines[0])
+	}
+}
@@ -0,0 +1,126 @@
+// Package dilemma runs Axelrod-style iterated prisoner's dilemma
+// tournaments and the population dynamics they imply.
+package dilemma
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+)
+
+type Move byte
+
+const (
+	Cooperate Move = 'C'
+	Defect    Move = 'D'
+)
+
+// Chooses moves in a match. Strategies keep no state between calls, so one
+// value can play any number of matches at once.
+type Strategy interface {
+	Name() string
+
+	// The next move, given both players' moves so far
+	Move(mine, theirs []Move, rng *rand.Rand) Move
+}
+
+// Cooperates first, then copies the opponent's last move
+type TitForTat struct{}
+
+func (TitForTat) Name() string { return "tft" }
+
+func (TitForTat) Move(mine, theirs []Move, rng *rand.Rand) Move {
+	if len(theirs) == 0 {
+		return Cooperate
+	}
+	return theirs[len(theirs)-1]
+}
+
+// Cooperates until the opponent defects once, then always defects
+type Grim struct{}
+
+func (Grim) Name() string { return "grim" }
+
+func (Grim) Move(mine, theirs []Move, rng *rand.Rand) Move {
+	for _, move := range theirs {
+		if move == Defect {
+			return Defect
+		}
+	}
+	return Cooperate
+}
+
+// Win-stay, lose-shift: repeats its last move after the opponent
+// cooperated and switches after they defected
+type Pavlov struct{}
+
+func (Pavlov) Name() string { return "pavlov" }
+
+func (Pavlov) Move(mine, theirs []Move, rng *rand.Rand) Move {
+	if len(mine) == 0 {
+		return Cooperate
+	}
+	last := mine[len(mine)-1]
+	if theirs[len(theirs)-1] == Cooperate {
+		return last
+	}
+	if last == Cooperate {
+		return Defect
+	}
+	return Cooperate
+}
+
+// Cooperates with probability P
+type Random struct {
+	P float64
+}
+
+func (Random) Name() string { return "random" }
+
+func (r Random) Move(mine, theirs []Move, rng *rand.Rand) Move {
+	if rng.Float64() < r.P {
+		return Cooperate
+	}
+	return Defect
+}
+
+type AlwaysDefect struct{}
+
+func (AlwaysDefect) Name() string { return "alld" }
+
+func (AlwaysDefect) Move(mine, theirs []Move, rng *rand.Rand) Move {
+	return Defect
+}
+
+type AlwaysCooperate struct{}
+
+func (AlwaysCooperate) Name() string { return "allc" }
+
+func (AlwaysCooperate) Move(mine, theirs []Move, rng *rand.Rand) Move {
+	return Cooperate
+}
+
+// Every built in strategy
+func Strategies() []Strategy {
+	return []Strategy{TitForTat{}, Grim{}, Pavlov{}, Random{P: 0.5}, AlwaysDefect{}, AlwaysCooperate{}}
+}
+
+// Looks up built in strategies by name from a comma separated list
+func Parse(names string) ([]Strategy, error) {
+	var strategies []Strategy
+	for _, name := range strings.Split(names, ",") {
+		name = strings.TrimSpace(name)
+		found := false
+		for _, s := range Strategies() {
+			if s.Name() == name {
+				strategies = append(strategies, s)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("dilemma: unknown strategy %q", name)
+		}
+	}
+	return strategies, nil
+}
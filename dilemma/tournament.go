@@ -0,0 +1,189 @@
+package dilemma
+
+import (
+	"fmt"
+	"io"
+	"math/rand"
+	"sort"
+	"sync"
+)
+
+// Payoff to a player for each outcome: Temptation to defect against a
+// cooperator, Reward for mutual cooperation, Punishment for mutual
+// defection and the Sucker's payoff for cooperating with a defector
+type Payoffs struct {
+	T, R, P, S float64
+}
+
+// The payoffs in Axelrod's tournaments
+var DefaultPayoffs = Payoffs{T: 5, R: 3, P: 1, S: 0}
+
+func (p Payoffs) score(mine, theirs Move) float64 {
+	switch {
+	case mine == Cooperate && theirs == Cooperate:
+		return p.R
+	case mine == Cooperate:
+		return p.S
+	case theirs == Cooperate:
+		return p.T
+	}
+	return p.P
+}
+
+type Match struct {
+	Rounds  int
+	Payoffs Payoffs
+
+	// Chance each move comes out the opposite of what was meant
+	Noise float64
+}
+
+// Plays a and b against each other and returns their mean scores per round
+// and the moves made
+func (m *Match) Play(a, b Strategy, rng *rand.Rand) (float64, float64, [2][]Move) {
+	var moves [2][]Move
+	var scores [2]float64
+
+	for round := 0; round < m.Rounds; round++ {
+		moveA := m.noisy(a.Move(moves[0], moves[1], rng), rng)
+		moveB := m.noisy(b.Move(moves[1], moves[0], rng), rng)
+		moves[0] = append(moves[0], moveA)
+		moves[1] = append(moves[1], moveB)
+
+		scores[0] += m.Payoffs.score(moveA, moveB)
+		scores[1] += m.Payoffs.score(moveB, moveA)
+	}
+
+	if m.Rounds == 0 {
+		return 0, 0, moves
+	}
+	return scores[0] / float64(m.Rounds), scores[1] / float64(m.Rounds), moves
+}
+
+func (m *Match) noisy(move Move, rng *rand.Rand) Move {
+	if m.Noise > 0 && rng.Float64() < m.Noise {
+		if move == Cooperate {
+			return Defect
+		}
+		return Cooperate
+	}
+	return move
+}
+
+// A round robin where every strategy, including itself, meets every other
+type Tournament struct {
+	Strategies []Strategy
+	Match      Match
+
+	// Matches per pairing, averaged
+	Repetitions int
+
+	Seed int64
+}
+
+func NewTournament(strategies []Strategy) *Tournament {
+	return &Tournament{
+		Strategies:  strategies,
+		Match:       Match{Rounds: 200, Payoffs: DefaultPayoffs},
+		Repetitions: 10,
+		Seed:        1,
+	}
+}
+
+type Results struct {
+	Names []string
+
+	// Scores[i][j] is the mean score per round of strategy i against j
+	Scores [][]float64
+}
+
+// Plays every pairing at the same time, each with its own random source
+func (t *Tournament) Run() *Results {
+	n := len(t.Strategies)
+	results := &Results{Names: make([]string, n), Scores: make([][]float64, n)}
+	for i, s := range t.Strategies {
+		results.Names[i] = s.Name()
+		results.Scores[i] = make([]float64, n)
+	}
+
+	wg := &sync.WaitGroup{}
+	for i := 0; i < n; i++ {
+		for j := i; j < n; j++ {
+			wg.Add(1)
+			go t.playPairing(i, j, results, wg)
+		}
+	}
+	wg.Wait()
+	return results
+}
+
+// Each pairing writes only its own cells
+func (t *Tournament) playPairing(i, j int, results *Results, wg *sync.WaitGroup) {
+	defer wg.Done()
+
+	rng := rand.New(rand.NewSource(t.Seed + int64(i*len(t.Strategies)+j)))
+	var totalI, totalJ float64
+	for rep := 0; rep < t.Repetitions; rep++ {
+		scoreI, scoreJ, _ := t.Match.Play(t.Strategies[i], t.Strategies[j], rng)
+		totalI += scoreI
+		totalJ += scoreJ
+	}
+
+	reps := float64(t.Repetitions)
+	if i == j {
+		// Both sides are the same strategy
+		results.Scores[i][i] = (totalI + totalJ) / (2 * reps)
+		return
+	}
+	results.Scores[i][j] = totalI / reps
+	results.Scores[j][i] = totalJ / reps
+}
+
+// Mean score per round of each strategy over all its opponents
+func (r *Results) Averages() []float64 {
+	averages := make([]float64, len(r.Names))
+	for i, row := range r.Scores {
+		for _, v := range row {
+			averages[i] += v
+		}
+		averages[i] /= float64(len(row))
+	}
+	return averages
+}
+
+// Strategy indexes from best average to worst
+func (r *Results) Ranking() []int {
+	averages := r.Averages()
+	order := make([]int, len(averages))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool { return averages[order[a]] > averages[order[b]] })
+	return order
+}
+
+// Writes the ranking then the table of pairwise scores
+func WriteReport(w io.Writer, r *Results) error {
+	averages := r.Averages()
+	for place, i := range r.Ranking() {
+		if _, err := fmt.Fprintf(w, "%2d. %-8s %.3f\n", place+1, r.Names[i], averages[i]); err != nil {
+			return err
+		}
+	}
+
+	fmt.Fprintf(w, "\n%-8s", "")
+	for _, name := range r.Names {
+		fmt.Fprintf(w, " %8s", name)
+	}
+	fmt.Fprintln(w)
+	for i, row := range r.Scores {
+		fmt.Fprintf(w, "%-8s", r.Names[i])
+		for _, v := range row {
+			fmt.Fprintf(w, " %8.3f", v)
+		}
+		if _, err := fmt.Fprintln(w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
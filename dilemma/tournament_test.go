@@ -0,0 +1,87 @@
+package dilemma
+
+import (
+	"bytes"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+func TestStrategies(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	tests := []struct {
+		s      Strategy
+		mine   string
+		theirs string
+		want   Move
+	}{
+		{TitForTat{}, "", "", Cooperate},
+		{TitForTat{}, "CC", "CD", Defect},
+		{Grim{}, "CCD", "CDC", Defect},
+		{Grim{}, "CC", "CC", Cooperate},
+		{Pavlov{}, "D", "C", Defect},
+		{Pavlov{}, "D", "D", Cooperate},
+		{Pavlov{}, "C", "D", Defect},
+		{AlwaysDefect{}, "", "", Defect},
+		{Random{P: 1}, "", "", Cooperate},
+	}
+
+	for _, test := range tests {
+		if got := test.s.Move([]Move(test.mine), []Move(test.theirs), rng); got != test.want {
+			t.Errorf("%s after %s/%s should play %c, got %c", test.s.Name(), test.mine, test.theirs, test.want, got)
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	strategies, err := Parse("tft, alld")
+	if err != nil || len(strategies) != 2 || strategies[1].Name() != "alld" {
+		t.Errorf("Should look up strategies, got %v %v", strategies, err)
+	}
+	if _, err := Parse("tft,nice"); err == nil {
+		t.Error("Should reject unknown strategies")
+	}
+}
+
+func TestMatch(t *testing.T) {
+	m := &Match{Rounds: 10, Payoffs: DefaultPayoffs}
+	rng := rand.New(rand.NewSource(1))
+
+	a, b, moves := m.Play(TitForTat{}, AlwaysDefect{}, rng)
+	if a != 0.9 || b != 1.4 || string(moves[0]) != "CDDDDDDDDD" {
+		t.Errorf("Tit for tat should lose only the first round to a defector, got %v %v %s", a, b, moves[0])
+	}
+
+	m.Noise = 1
+	a, b, _ = m.Play(AlwaysDefect{}, AlwaysDefect{}, rng)
+	if a != 3 || b != 3 {
+		t.Errorf("Full noise should turn defection into cooperation, got %v %v", a, b)
+	}
+}
+
+func TestTournament(t *testing.T) {
+	tournament := NewTournament([]Strategy{TitForTat{}, Grim{}, AlwaysDefect{}, AlwaysCooperate{}})
+	results := tournament.Run()
+
+	if results.Scores[0][1] != 3 || results.Scores[3][2] != 0 || results.Scores[2][3] != 5 {
+		t.Errorf("Should score pairings without noise exactly: %v", results.Scores)
+	}
+	if rank := results.Ranking(); results.Names[rank[0]] != "tft" || results.Names[rank[len(rank)-1]] != "alld" {
+		t.Errorf("Tit for tat should win and always defecting come last, got %v", rank)
+	}
+
+	// Same seed, same results
+	again := tournament.Run()
+	for i := range again.Scores {
+		for j := range again.Scores[i] {
+			if again.Scores[i][j] != results.Scores[i][j] {
+				t.Fatal("Should be repeatable with the same seed")
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := WriteReport(&buf, results); err != nil || !strings.Contains(buf.String(), " 1. ") {
+		t.Errorf("Should write a ranking:\n%s", buf.String())
+	}
+}
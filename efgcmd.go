@@ -0,0 +1,70 @@
+package main
+
+import (
+	"FinalProject/extensive"
+	"FinalProject/game"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+)
+
+// Solves an extensive form game for a subgame perfect equilibrium. The game
+// comes from a Gambit .efg file or from the first moves of one of the arena
+// games, e.g.
+//
+//	efg entry.efg
+//	efg -game tictactoe -depth 3 -out tictactoe.efg
+func efgCommand(args []string) {
+	flags := flag.NewFlagSet("efg", flag.ExitOnError)
+	name := flags.String("game", "", "build the tree of this game instead of reading a file: connect4, tictactoe or gomoku")
+	depth := flags.Int("depth", 2, "moves of -game to include")
+	size := flags.Int("size", 9, "gomoku board size")
+	length := flags.Int("length", 5, "gomoku stones in a row to win")
+	out := flags.String("out", "", "write the game in .efg format to this file")
+	quiet := flags.Bool("quiet", false, "only print the equilibrium payoffs")
+	flags.Parse(args)
+
+	var tree *extensive.Game
+	if *name != "" {
+		g, err := newGame(*name, *size, *length)
+		exitIf(err)
+
+		// Connect Four columns are numbered from 1
+		var moveName func(int) string
+		if _, ok := g.(*game.ConnectFour); ok {
+			moveName = func(move int) string { return strconv.Itoa(move + 1) }
+		}
+		tree = extensive.FromGame(g, *depth, [2]string{string(game.Tokens[0]), string(game.Tokens[1])}, moveName)
+		tree.Title = *name
+	} else {
+		if flags.NArg() != 1 {
+			exitIf(fmt.Errorf("usage: efg [-out file] game.efg, or efg -game name [-depth n]"))
+		}
+		f, err := os.Open(flags.Arg(0))
+		exitIf(err)
+		tree, err = extensive.Parse(f)
+		f.Close()
+		exitIf(err)
+	}
+
+	if *out != "" {
+		f, err := os.Create(*out)
+		exitIf(err)
+		if err := extensive.Write(f, tree); err != nil {
+			f.Close()
+			exitIf(err)
+		}
+		exitIf(f.Close())
+		fmt.Printf("Wrote %s (%d nodes)\n", *out, len(tree.Nodes()))
+	}
+
+	profile, values, err := tree.SubgamePerfect()
+	exitIf(err)
+	if !*quiet {
+		exitIf(extensive.WriteProfile(os.Stdout, tree, profile))
+	}
+	for p, player := range tree.Players {
+		fmt.Printf("%s expects %.4g\n", player, values[p])
+	}
+}
@@ -0,0 +1,183 @@
+package engine
+
+import (
+	"FinalProject/game"
+	"os"
+	"strings"
+	"testing"
+)
+
+// When run with this variable set the test binary acts as an engine, so
+// ExternalPlayer can be tested against a real process
+func TestMain(m *testing.M) {
+	if os.Getenv("ENGINE_TEST_SERVE") == "1" {
+		Serve(os.Stdin, os.Stdout)
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
+func run(t *testing.T, input string) []string {
+	t.Helper()
+	var out strings.Builder
+	if err := Serve(strings.NewReader(input), &out); err != nil {
+		t.Fatal(err)
+	}
+	return strings.Split(strings.TrimSpace(out.String()), "\n")
+}
+
+func TestHandshake(t *testing.T) {
+	lines := run(t, "uci\nisready\nquit\nisready\n")
+
+	if len(lines) != 3 || lines[1] != "uciok" || lines[2] != "readyok" {
+		t.Errorf("Unexpected handshake: %q", lines)
+	}
+}
+
+func TestGoDepth(t *testing.T) {
+	lines := run(t, "position moves 121212\ngo depth 2\n")
+
+	if len(lines) != 3 {
+		t.Fatalf("Expected two info lines and a bestmove: %q", lines)
+	}
+
+	if !strings.HasPrefix(lines[0], "info depth 1 score ") || !strings.HasSuffix(lines[1], " pv 1") {
+		t.Errorf("Unexpected info lines: %q", lines)
+	}
+
+	if lines[2] != "bestmove 1" {
+		t.Errorf("Engine should win in column 1, said %q", lines[2])
+	}
+}
+
+func TestGoMoveTime(t *testing.T) {
+	lines := run(t, "position startpos moves 44\ngo movetime 20\n")
+
+	last := lines[len(lines)-1]
+	if !strings.HasPrefix(last, "bestmove ") || len(lines) < 2 {
+		t.Errorf("Timed search should report and answer: %q", lines)
+	}
+}
+
+func TestPositionBoard(t *testing.T) {
+	board := game.NewBoard()
+	board.PlayMoves([]int{0, 1, 0, 1, 0})
+
+	lines := run(t, "position board "+board.Encode()+"\ngo depth 2\n")
+
+	// O has to block the column
+	if lines[len(lines)-1] != "bestmove 1" {
+		t.Errorf("O should block column 1: %q", lines)
+	}
+}
+
+func TestErrors(t *testing.T) {
+	lines := run(t, "position moves 9\ngo depth 99\nfly\nposition moves 1212121\ngo\n")
+
+	for i, want := range []string{"info string", "info string", "bestmove none", "info string", "bestmove none"} {
+		if !strings.HasPrefix(lines[i], want) {
+			t.Errorf("Line %d should start with %q: %q", i, want, lines[i])
+		}
+	}
+}
+
+func TestBadGoStillAnswers(t *testing.T) {
+	lines := run(t, "go depth\ngo movetime -5\n")
+
+	want := []string{"info string", "bestmove none", "info string", "bestmove none"}
+	if len(lines) != len(want) {
+		t.Fatalf("Each go should end with a bestmove: %q", lines)
+	}
+	for i := range want {
+		if !strings.HasPrefix(lines[i], want[i]) {
+			t.Errorf("Line %d should start with %q: %q", i, want[i], lines[i])
+		}
+	}
+}
+
+func TestExternalPlayer(t *testing.T) {
+	exe, err := os.Executable()
+	if err != nil {
+		t.Skip(err)
+	}
+	os.Setenv("ENGINE_TEST_SERVE", "1")
+	defer os.Unsetenv("ENGINE_TEST_SERVE")
+
+	player, err := NewExternalPlayer(exe)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer player.Close()
+	player.Depth = 2
+
+	var infos int
+	player.OnInfo = func(line string) { infos++ }
+
+	board := game.NewBoard()
+	board.PlayMoves([]int{0, 1, 0, 1, 0, 1})
+
+	if move := player.MakeMove(board); move != 0 || board.Winner != ' ' && board.Winner != 'X' {
+		t.Errorf("External engine should win in column 1, played %d", move+1)
+	}
+
+	if player.Err() != nil {
+		t.Error(player.Err())
+	}
+
+	if infos != 2 {
+		t.Errorf("Should have seen an info line per depth, saw %d", infos)
+	}
+}
+
+func TestExternalPlayerSendsMoves(t *testing.T) {
+	player := &ExternalPlayer{}
+	board := game.NewBoard()
+
+	if got := player.position(board); got != "position startpos" {
+		t.Errorf("Empty board should be the start position, got %q", got)
+	}
+	player.moves = append(player.moves, 3)
+	board.PlayMoves([]int{3, 2})
+
+	if got := player.position(board); got != "position startpos moves 43" {
+		t.Errorf("Should follow the game move by move, got %q", got)
+	}
+	player.moves = append(player.moves, 3)
+	board.PlayMoves([]int{3, 0, 6})
+
+	if got := player.position(board); got != "position board "+board.Encode() {
+		t.Errorf("Should fall back to the board after losing track, got %q", got)
+	}
+
+	other := game.NewBoard()
+	other.WhoseTurn = 1
+	other.MakeMove(5)
+	if got := (&ExternalPlayer{}).position(other); got != "position board "+other.Encode() {
+		t.Errorf("O moving first isn't the start position, got %q", got)
+	}
+}
+
+// An engine with no move leaves it to Fallback instead of hanging
+func TestExternalPlayerNoMove(t *testing.T) {
+	exe, err := os.Executable()
+	if err != nil {
+		t.Skip(err)
+	}
+	os.Setenv("ENGINE_TEST_SERVE", "1")
+	defer os.Unsetenv("ENGINE_TEST_SERVE")
+
+	player, err := NewExternalPlayer(exe)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer player.Close()
+	player.Depth = 2
+
+	// X has already won, so the engine answers bestmove none
+	board := game.NewBoard()
+	board.PlayMoves([]int{0, 1, 0, 1, 0, 1, 0})
+
+	if move := player.MakeMove(board); move < 0 || player.Err() == nil {
+		t.Errorf("Should fall back and report the missing move, played %d with error %v", move+1, player.Err())
+	}
+}
@@ -0,0 +1,183 @@
+package engine
+
+import (
+	"FinalProject/game"
+	"bufio"
+	"fmt"
+	"io"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// A player backed by another engine process speaking this package's
+// protocol. Each move sends the columns played from the empty board, as UCI
+// engines expect, or the whole board when the game didn't start from it.
+//
+// Like netplay.RemotePlayer, if the engine dies or answers with nonsense
+// the move is made by Fallback and Err reports why.
+type ExternalPlayer struct {
+	// Passed to "go". MoveTime wins if both are set.
+	Depth    int
+	MoveTime time.Duration
+
+	Fallback game.Player
+
+	// Called with each line the engine sends while searching, such as info
+	OnInfo func(line string)
+
+	cmd *exec.Cmd
+	in  io.WriteCloser
+	out *bufio.Reader
+	err error
+
+	// Columns played from the empty board up to the engine's last move, or
+	// nil if the game can't be followed from it
+	moves []int
+}
+
+// Starts the engine and waits for it to finish the uci handshake
+func NewExternalPlayer(name string, args ...string) (*ExternalPlayer, error) {
+	cmd := exec.Command(name, args...)
+	in, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	out, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+
+	player := &ExternalPlayer{
+		Depth:    DefaultDepth,
+		Fallback: &game.RandomPlayer{},
+		cmd:      cmd,
+		in:       in,
+		out:      bufio.NewReader(out),
+	}
+
+	if err := player.send("uci"); err != nil {
+		player.Close()
+		return nil, err
+	}
+	if _, err := player.waitFor("uciok"); err != nil {
+		player.Close()
+		return nil, fmt.Errorf("engine: %s didn't finish the handshake: %w", name, err)
+	}
+	return player, nil
+}
+
+func (player *ExternalPlayer) MakeMove(board *game.Board) int {
+	if player.err == nil {
+		move, err := player.askMove(board)
+		if err == nil {
+			board.MakeMove(move)
+			return move
+		}
+		player.err = err
+	}
+
+	return player.Fallback.MakeMove(board)
+}
+
+func (player *ExternalPlayer) askMove(board *game.Board) (int, error) {
+	goCommand := fmt.Sprintf("go depth %d", player.Depth)
+	if player.MoveTime > 0 {
+		goCommand = fmt.Sprintf("go movetime %d", player.MoveTime.Milliseconds())
+	}
+
+	if err := player.send(player.position(board)); err != nil {
+		return 0, err
+	}
+	if err := player.send(goCommand); err != nil {
+		return 0, err
+	}
+
+	line, err := player.waitFor("bestmove")
+	if err != nil {
+		return 0, err
+	}
+
+	reply := strings.TrimSpace(strings.TrimPrefix(line, "bestmove"))
+	if reply == "none" {
+		return 0, fmt.Errorf("engine: no move for %s", board.Encode())
+	}
+	col, err := strconv.Atoi(reply)
+	if err != nil || col < 1 || col > game.NumCols || !board.IsValidMove(col-1) {
+		return 0, fmt.Errorf("engine: illegal reply %q", line)
+	}
+	if player.moves != nil {
+		player.moves = append(player.moves, col-1)
+	}
+	return col - 1, nil
+}
+
+// Returns the position command for board. The moves since the engine's
+// last move are worked out by trying each column, so the game is followed
+// as long as the opponent makes one move at a time from the empty board.
+func (player *ExternalPlayer) position(board *game.Board) string {
+	encoded := board.Encode()
+	for _, before := range [][]int{player.moves, {}} {
+		if before == nil {
+			continue
+		}
+		for c := -1; c < game.NumCols; c++ {
+			moves := append([]int{}, before...)
+			if c >= 0 {
+				moves = append(moves, c)
+			}
+
+			replay := game.NewBoard()
+			if replay.PlayMoves(moves) == nil && replay.Encode() == encoded {
+				player.moves = moves
+				if len(moves) == 0 {
+					return "position startpos"
+				}
+				return "position startpos moves " + game.FormatMoves(moves)
+			}
+		}
+	}
+
+	player.moves = nil
+	return "position board " + encoded
+}
+
+func (player *ExternalPlayer) send(line string) error {
+	_, err := io.WriteString(player.in, line+"\n")
+	return err
+}
+
+// Reads lines until one starts with the given word, passing the rest to
+// OnInfo
+func (player *ExternalPlayer) waitFor(word string) (string, error) {
+	for {
+		line, err := player.out.ReadString('\n')
+		if err != nil {
+			return "", err
+		}
+
+		line = strings.TrimSpace(line)
+		if fields := strings.Fields(line); len(fields) > 0 && fields[0] == word {
+			return line, nil
+		}
+		if player.OnInfo != nil && line != "" {
+			player.OnInfo(line)
+		}
+	}
+}
+
+// Returns the first error talking to the engine, if any
+func (player *ExternalPlayer) Err() error {
+	return player.err
+}
+
+// Asks the engine to quit and waits for it to exit
+func (player *ExternalPlayer) Close() error {
+	player.send("quit")
+	player.in.Close()
+	return player.cmd.Wait()
+}
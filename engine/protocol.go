@@ -0,0 +1,179 @@
+// Package engine speaks a UCI-style text protocol so the search can be
+// driven by GUIs and test harnesses, and so other engines speaking the same
+// protocol can be used as players.
+//
+// Commands, one per line:
+//
+//	uci                          reply with id lines and uciok
+//	isready                      reply readyok
+//	newgame                      reset to the empty board
+//	position moves 4453          the empty board plus these moves (columns 1-7)
+//	position startpos [moves ..] same, spelled the UCI way
+//	position board <board> [moves ..]
+//	                             a board written by game.Board.Encode
+//	go [depth N] [movetime MS]   search, then reply bestmove
+//	quit                         stop reading
+//
+// While searching the engine sends one line per finished depth:
+//
+//	info depth 3 score 12 pv 434
+//
+// then the answer, "bestmove 4", or "bestmove none" if the game is over or
+// the go command couldn't be read. Every go gets a bestmove.
+// Scores are for the side to move. Unknown commands get an
+// "info string ..." reply and are otherwise ignored.
+package engine
+
+import (
+	"FinalProject/game"
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	Name = "GameTheoryProject"
+
+	DefaultDepth = 5
+	MaxDepth     = 8
+)
+
+// Answers protocol commands read from in until quit or the end of input
+func Serve(in io.Reader, out io.Writer) error {
+	scanner := bufio.NewScanner(in)
+	board := game.NewBoard()
+
+	reply := func(format string, args ...interface{}) {
+		fmt.Fprintf(out, format+"\n", args...)
+	}
+
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+
+		switch fields[0] {
+		case "uci":
+			reply("id name %s", Name)
+			reply("uciok")
+		case "isready":
+			reply("readyok")
+		case "newgame", "ucinewgame":
+			board = game.NewBoard()
+		case "position":
+			b, err := parsePosition(fields[1:])
+			if err != nil {
+				reply("info string %v", err)
+				continue
+			}
+			board = b
+		case "go":
+			depth, moveTime, err := parseGo(fields[1:])
+			if err != nil {
+				reply("info string %v", err)
+				reply("bestmove none")
+				continue
+			}
+			search(board, depth, moveTime, reply)
+		case "quit":
+			return nil
+		default:
+			reply("info string unknown command %q", fields[0])
+		}
+	}
+	return scanner.Err()
+}
+
+func search(board *game.Board, depth int, moveTime time.Duration, reply func(string, ...interface{})) {
+	if board.CheckEndGame() {
+		reply("bestmove none")
+		return
+	}
+
+	start := time.Now()
+	analysis := game.AnalyzeDeepening(board, depth, moveTime, func(a game.Analysis) {
+		reply("info depth %d score %d time %d pv %s", a.Depth, a.Value, time.Since(start).Milliseconds(), game.FormatMoves(a.Line))
+	})
+	reply("bestmove %d", analysis.Move+1)
+}
+
+func parsePosition(args []string) (*game.Board, error) {
+	if len(args) == 0 {
+		return nil, fmt.Errorf("position needs startpos, moves or board")
+	}
+
+	board := game.NewBoard()
+	switch args[0] {
+	case "startpos":
+		args = args[1:]
+	case "moves":
+	case "board":
+		if len(args) < 3 {
+			return nil, fmt.Errorf("position board needs a board and the side to move")
+		}
+		b, err := game.DecodeBoard(args[1] + " " + args[2])
+		if err != nil {
+			return nil, err
+		}
+		board = b
+		args = args[3:]
+	default:
+		return nil, fmt.Errorf("unknown position %q", args[0])
+	}
+
+	if len(args) == 0 {
+		return board, nil
+	}
+	if args[0] != "moves" {
+		return nil, fmt.Errorf("expected moves, got %q", args[0])
+	}
+
+	moves, err := game.ParseMoves(strings.Join(args[1:], ""))
+	if err != nil {
+		return nil, err
+	}
+	if err := board.PlayMoves(moves); err != nil {
+		return nil, err
+	}
+	return board, nil
+}
+
+// With only a move time the search goes as deep as MaxDepth allows
+func parseGo(args []string) (int, time.Duration, error) {
+	depth := 0
+	var moveTime time.Duration
+
+	for i := 0; i < len(args); i++ {
+		if i+1 >= len(args) {
+			return 0, 0, fmt.Errorf("go %s needs a value", args[i])
+		}
+		n, err := strconv.Atoi(args[i+1])
+		if err != nil || n <= 0 {
+			return 0, 0, fmt.Errorf("bad value %q for go %s", args[i+1], args[i])
+		}
+
+		switch args[i] {
+		case "depth":
+			depth = n
+		case "movetime":
+			moveTime = time.Duration(n) * time.Millisecond
+		default:
+			return 0, 0, fmt.Errorf("unknown go option %q", args[i])
+		}
+		i++
+	}
+
+	switch {
+	case depth == 0 && moveTime > 0:
+		depth = MaxDepth
+	case depth == 0:
+		depth = DefaultDepth
+	case depth > MaxDepth:
+		return 0, 0, fmt.Errorf("depth %d is deeper than the limit of %d", depth, MaxDepth)
+	}
+	return depth, moveTime, nil
+}
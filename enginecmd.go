@@ -0,0 +1,12 @@
+package main
+
+import (
+	"FinalProject/engine"
+	"os"
+)
+
+// Speaks the engine protocol on stdin and stdout so GUIs, test harnesses
+// and other copies of this program can drive the search
+func engineCommand(args []string) {
+	exitIf(engine.Serve(os.Stdin, os.Stdout))
+}
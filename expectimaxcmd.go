@@ -0,0 +1,54 @@
+package main
+
+import (
+	"FinalProject/game"
+	"flag"
+	"fmt"
+)
+
+// Plays an expectimax player and a minimax SmartPlayer of the same depth
+// against the same opponent to compare them
+func expectimaxCommand(args []string) {
+	flags := flag.NewFlagSet("expectimax", flag.ExitOnError)
+	depth := flags.Int("depth", 4, "search depth of both players")
+	model := flags.String("model", "uniform", "opponent model: uniform, softmax, or comma separated game records to learn from")
+	opponent := flags.String("opponent", "random", "player they both face: "+playerKinds)
+	numGames := flags.Int("games", 100, "games for each player, half of them moving first")
+	flags.Parse(args)
+
+	specs := []string{fmt.Sprintf("expectimax:%d:%s", *depth, *model), fmt.Sprintf("smart:%d", *depth)}
+	for _, spec := range append(specs, *opponent) {
+		player, err := newPlayer(spec, 0, nil)
+		exitIf(err)
+		closePlayers(player)
+	}
+
+	for _, spec := range specs {
+		results, err := compareAgainst(spec, *opponent, *numGames)
+		exitIf(err)
+		fmt.Printf("%-24s won %3d, lost %3d, drew %3d of %d against %s\n", spec, results[0], results[1], results[2], *numGames, *opponent)
+	}
+}
+
+// Plays spec as X against opponent as O, all games at once, and returns the
+// wins, losses and draws
+func compareAgainst(spec, opponent string, numGames int) ([3]int, error) {
+	sim := &simulation{numReps: numGames, players: [2]string{spec, opponent}}
+	winners, errs := sim.play()
+
+	var results [3]int
+	for i, winner := range winners {
+		if errs[i] != nil {
+			return results, errs[i]
+		}
+		switch winner {
+		case game.Tokens[0]:
+			results[0]++
+		case game.Tokens[1]:
+			results[1]++
+		default:
+			results[2]++
+		}
+	}
+	return results, nil
+}
@@ -0,0 +1,361 @@
+package extensive
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Games are read and written in Gambit's .efg text format:
+//
+//	EFG 2 R "Entry" { "Entrant" "Incumbent" }
+//	""
+//
+//	p "" 1 1 "" { "Out" "In" } 0
+//	t "" 1 "Out" { 0, 2 }
+//	p "" 2 1 "" { "Fight" "Accommodate" } 0
+//	t "" 2 "Fight" { -1, -1 }
+//	t "" 3 "Accommodate" { 1, 1 }
+//
+// Nodes come in prefix order: p for a player's decision, c for chance and t
+// for terminal. Each names its information set and outcome by number;
+// names, actions and payoffs only have to be given the first time a number
+// is used. An outcome of 0 means none.
+
+// Reads a game in .efg format
+func Parse(r io.Reader) (*Game, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokenize(string(data))}
+
+	if p.next() != "EFG" || p.next() != "2" || p.next() != "R" {
+		return nil, fmt.Errorf("extensive: not an EFG 2 R file")
+	}
+	g := &Game{numbers: make(map[int]int)}
+	if g.Title, err = p.quoted(); err != nil {
+		return nil, err
+	}
+	if g.Players, err = p.list(); err != nil {
+		return nil, err
+	}
+	if strings.HasPrefix(p.peek(), `"`) {
+		g.Comment, _ = p.quoted()
+	}
+
+	p.infoSets = make(map[[2]int]*InfoSet)
+	p.outcomes = make(map[int]*Outcome)
+	if g.Root, err = p.node(g, nil); err != nil {
+		return nil, err
+	}
+	if p.peek() != "" {
+		return nil, fmt.Errorf("extensive: unexpected %s after the last node", p.peek())
+	}
+	return g, nil
+}
+
+type parser struct {
+	tokens   []string
+	pos      int
+	infoSets map[[2]int]*InfoSet // by player and number
+	outcomes map[int]*Outcome
+}
+
+// Splits the text into quoted strings, with their quotes, braces and
+// words. Commas only separate.
+func tokenize(text string) []string {
+	var tokens []string
+	for i := 0; i < len(text); {
+		c := text[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\r' || c == '\n' || c == ',':
+			i++
+		case c == '{' || c == '}':
+			tokens = append(tokens, string(c))
+			i++
+		case c == '"':
+			j := i + 1
+			for j < len(text) && text[j] != '"' {
+				if text[j] == '\\' {
+					j++
+				}
+				j++
+			}
+			tokens = append(tokens, text[i:min(j+1, len(text))])
+			i = j + 1
+		default:
+			j := i
+			for j < len(text) && !strings.ContainsRune(" \t\r\n,{}\"", rune(text[j])) {
+				j++
+			}
+			tokens = append(tokens, text[i:j])
+			i = j
+		}
+	}
+	return tokens
+}
+
+func (p *parser) peek() string {
+	if p.pos >= len(p.tokens) {
+		return ""
+	}
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() string {
+	token := p.peek()
+	p.pos++
+	return token
+}
+
+func (p *parser) quoted() (string, error) {
+	token := p.next()
+	if !strings.HasPrefix(token, `"`) || len(token) < 2 || !strings.HasSuffix(token, `"`) {
+		return "", fmt.Errorf("extensive: expected a quoted string, got %q", token)
+	}
+	return strings.NewReplacer(`\"`, `"`, `\\`, `\`).Replace(token[1 : len(token)-1]), nil
+}
+
+func (p *parser) int() (int, error) {
+	token := p.next()
+	n, err := strconv.Atoi(token)
+	if err != nil {
+		return 0, fmt.Errorf("extensive: expected a number, got %q", token)
+	}
+	return n, nil
+}
+
+// Numbers may be decimals or fractions such as 1/3
+func (p *parser) number() (float64, error) {
+	token := p.next()
+	r, ok := new(big.Rat).SetString(token)
+	if !ok {
+		return 0, fmt.Errorf("extensive: bad number %q", token)
+	}
+	f, _ := r.Float64()
+	return f, nil
+}
+
+// A braced list of quoted strings
+func (p *parser) list() ([]string, error) {
+	if p.next() != "{" {
+		return nil, fmt.Errorf("extensive: expected {")
+	}
+	var items []string
+	for p.peek() != "}" {
+		item, err := p.quoted()
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	p.next()
+	return items, nil
+}
+
+func (p *parser) node(g *Game, parent *Node) (*Node, error) {
+	kind := p.next()
+	n := &Node{Parent: parent}
+	var err error
+	if n.Name, err = p.quoted(); err != nil {
+		return nil, err
+	}
+
+	player := Chance
+	switch kind {
+	case "t":
+		return n, p.outcome(g, n)
+	case "p":
+		if player, err = p.int(); err != nil {
+			return nil, err
+		}
+		if player < 1 || player > len(g.Players) {
+			return nil, fmt.Errorf("extensive: no player %d", player)
+		}
+	case "c":
+	default:
+		return nil, fmt.Errorf("extensive: unknown node type %q", kind)
+	}
+
+	number, err := p.int()
+	if err != nil {
+		return nil, err
+	}
+	set := p.infoSets[[2]int{player, number}]
+	if set == nil {
+		set = &InfoSet{Player: player, Number: number}
+		p.infoSets[[2]int{player, number}] = set
+		if number > g.numbers[player] {
+			g.numbers[player] = number
+		}
+	}
+
+	if strings.HasPrefix(p.peek(), `"`) {
+		if set.Name, err = p.quoted(); err != nil {
+			return nil, err
+		}
+	}
+	if p.peek() == "{" {
+		if err := p.actions(set); err != nil {
+			return nil, err
+		}
+	}
+	if len(set.Actions) == 0 {
+		return nil, fmt.Errorf("extensive: information set %d of player %d has no actions", number, player)
+	}
+	n.InfoSet = set
+	set.Nodes = append(set.Nodes, n)
+
+	if err := p.outcome(g, n); err != nil {
+		return nil, err
+	}
+	for range set.Actions {
+		child, err := p.node(g, n)
+		if err != nil {
+			return nil, err
+		}
+		n.Children = append(n.Children, child)
+	}
+	return n, nil
+}
+
+// Reads an action list, with probabilities for chance, checking it against
+// any earlier one for the same set
+func (p *parser) actions(set *InfoSet) error {
+	p.next()
+	var actions []string
+	var probs []float64
+	for p.peek() != "}" {
+		if p.peek() == "" {
+			return fmt.Errorf("extensive: unclosed action list")
+		}
+		action, err := p.quoted()
+		if err != nil {
+			return err
+		}
+		actions = append(actions, action)
+		if set.Player == Chance {
+			prob, err := p.number()
+			if err != nil {
+				return err
+			}
+			probs = append(probs, prob)
+		}
+	}
+	p.next()
+
+	if set.Actions != nil && len(actions) != len(set.Actions) {
+		return fmt.Errorf("extensive: information set %d of player %d has %d actions, then %d", set.Number, set.Player, len(set.Actions), len(actions))
+	}
+	set.Actions = actions
+	set.Probs = probs
+	return nil
+}
+
+func (p *parser) outcome(g *Game, n *Node) error {
+	number, err := p.int()
+	if err != nil {
+		return err
+	}
+
+	var outcome *Outcome
+	if number != 0 {
+		outcome = p.outcomes[number]
+		if outcome == nil {
+			outcome = &Outcome{}
+			p.outcomes[number] = outcome
+		}
+	}
+
+	if strings.HasPrefix(p.peek(), `"`) {
+		name, _ := p.quoted()
+		if outcome != nil {
+			outcome.Name = name
+		}
+	}
+	if p.peek() == "{" {
+		p.next()
+		var payoffs []float64
+		for p.peek() != "}" {
+			v, err := p.number()
+			if err != nil {
+				return err
+			}
+			payoffs = append(payoffs, v)
+		}
+		p.next()
+
+		if outcome == nil {
+			return fmt.Errorf("extensive: payoffs given for outcome 0")
+		}
+		if len(payoffs) != len(g.Players) {
+			return fmt.Errorf("extensive: %d payoffs for %d players", len(payoffs), len(g.Players))
+		}
+		outcome.Payoffs = payoffs
+	}
+
+	if outcome != nil && outcome.Payoffs == nil {
+		return fmt.Errorf("extensive: outcome %d has no payoffs", number)
+	}
+	n.Outcome = outcome
+	return nil
+}
+
+// Writes the game in .efg format, numbering outcomes in the order they're
+// reached
+func Write(w io.Writer, g *Game) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "EFG 2 R %s {", quote(g.Title))
+	for _, player := range g.Players {
+		fmt.Fprintf(bw, " %s", quote(player))
+	}
+	fmt.Fprintf(bw, " }\n%s\n\n", quote(g.Comment))
+
+	outcomes := make(map[*Outcome]int)
+	for _, n := range g.Nodes() {
+		switch {
+		case n.Terminal():
+			fmt.Fprintf(bw, "t %s", quote(n.Name))
+		case n.Player() == Chance:
+			fmt.Fprintf(bw, "c %s %d %s {", quote(n.Name), n.InfoSet.Number, quote(n.InfoSet.Name))
+			for i, action := range n.InfoSet.Actions {
+				fmt.Fprintf(bw, " %s %s", quote(action), formatNumber(n.InfoSet.Probs[i]))
+			}
+			fmt.Fprint(bw, " }")
+		default:
+			fmt.Fprintf(bw, "p %s %d %d %s {", quote(n.Name), n.Player(), n.InfoSet.Number, quote(n.InfoSet.Name))
+			for _, action := range n.InfoSet.Actions {
+				fmt.Fprintf(bw, " %s", quote(action))
+			}
+			fmt.Fprint(bw, " }")
+		}
+
+		if n.Outcome == nil {
+			fmt.Fprintln(bw, " 0")
+			continue
+		}
+		number, ok := outcomes[n.Outcome]
+		if !ok {
+			number = len(outcomes) + 1
+			outcomes[n.Outcome] = number
+		}
+		payoffs := make([]string, len(n.Outcome.Payoffs))
+		for i, v := range n.Outcome.Payoffs {
+			payoffs[i] = formatNumber(v)
+		}
+		fmt.Fprintf(bw, " %d %s { %s }\n", number, quote(n.Outcome.Name), strings.Join(payoffs, ", "))
+	}
+	return bw.Flush()
+}
+
+func quote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
+
+func formatNumber(v float64) string {
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
@@ -0,0 +1,96 @@
+package extensive
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+const entryEFG = `EFG 2 R "Entry" { "Entrant" "Incumbent" }
+"Selten's entry game"
+
+p "" 1 1 "" { "Out" "In" } 0
+t "" 1 "Out" { 0, 2 }
+p "" 2 1 "" { "Fight" "Accommodate" } 0
+t "" 2 "Fight" { -1, -1 }
+t "" 3 "Accommodate" { 1, 1 }
+`
+
+func mustParse(t *testing.T, text string) *Game {
+	t.Helper()
+	g, err := Parse(strings.NewReader(text))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return g
+}
+
+func TestParse(t *testing.T) {
+	g := mustParse(t, entryEFG)
+	if g.Title != "Entry" || g.Comment != "Selten's entry game" || len(g.Players) != 2 {
+		t.Errorf("Should read the header: %+v", g)
+	}
+
+	in := g.Root.Children[1]
+	if g.Root.Player() != 1 || in.Player() != 2 || in.InfoSet.Actions[1] != "Accommodate" {
+		t.Errorf("Should read the decisions: %+v", in.InfoSet)
+	}
+	if leaf := in.Children[0]; !leaf.Terminal() || leaf.Outcome.Name != "Fight" || leaf.Outcome.Payoffs[1] != -1 {
+		t.Errorf("Should read the outcomes: %+v", leaf.Outcome)
+	}
+	if len(g.Nodes()) != 5 || len(g.InfoSets()) != 2 {
+		t.Errorf("Should have 5 nodes in 2 sets, got %d and %d", len(g.Nodes()), len(g.InfoSets()))
+	}
+}
+
+// Later nodes in a set and outcomes used again can leave out their details
+func TestParseShortForms(t *testing.T) {
+	g := mustParse(t, `EFG 2 R "Pennies" { "1" "2" }
+c "" 1 "" { "H" 1/3 "T" 2/3 } 0
+p "" 2 1 "" { "h" "t" } 0
+t "" 1 "Win" { 1 -1 }
+t "" 2 "Lose" { -1 1 }
+p "" 2 1 0
+t "" 2
+t "" 1
+`)
+
+	set := g.Root.Children[0].InfoSet
+	if len(set.Nodes) != 2 || g.Root.Children[1].InfoSet != set || g.Root.InfoSet.Probs[0] != 1.0/3 {
+		t.Errorf("Should share the information set: %+v", set)
+	}
+	if g.Root.Children[1].Children[1].Outcome != g.Root.Children[0].Children[0].Outcome {
+		t.Error("Should share outcomes by number")
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, text := range []string{
+		``,
+		`EFG 2 R "" { "1" } p "" 2 1 "" { "a" } 0 t "" 0`,
+		`EFG 2 R "" { "1" } p "" 1 1 "" { } 0`,
+		`EFG 2 R "" { "1" } t "" 1 { 1 2 }`,
+		`EFG 2 R "" { "1" } t "" 1`,
+		`EFG 2 R "" { "1" } t "" 0 t "" 0`,
+	} {
+		if _, err := Parse(strings.NewReader(text)); err == nil {
+			t.Errorf("Should reject %q", text)
+		}
+	}
+}
+
+func TestWriteRoundTrip(t *testing.T) {
+	g := mustParse(t, entryEFG)
+	var buf bytes.Buffer
+	if err := Write(&buf, g); err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != entryEFG {
+		t.Errorf("Should write the game as read:\n%s", buf.String())
+	}
+
+	again := mustParse(t, buf.String())
+	if len(again.Nodes()) != len(g.Nodes()) {
+		t.Error("Should read back what it writes")
+	}
+}
@@ -0,0 +1,39 @@
+package extensive
+
+import (
+	"FinalProject/game"
+	"strconv"
+)
+
+// Writes out the first depth moves of a two player game as a tree with
+// perfect information, with each player's Utility as the payoffs where it
+// stops. moveName labels the actions; nil numbers them as the game does.
+func FromGame(g game.Game, depth int, players [2]string, moveName func(move int) string) *Game {
+	if moveName == nil {
+		moveName = strconv.Itoa
+	}
+	tree := New("", players[0], players[1])
+
+	var build func(n *Node, depth int)
+	build = func(n *Node, depth int) {
+		if depth == 0 || g.Terminal() {
+			n.SetPayoffs(float64(g.Utility(0)), float64(g.Utility(1)))
+			return
+		}
+
+		moves := g.Moves()
+		actions := make([]string, len(moves))
+		for i, move := range moves {
+			actions[i] = moveName(move)
+		}
+		n.Expand(tree.NewInfoSet(g.CurrentPlayer()+1, "", actions...))
+
+		for i, move := range moves {
+			g.Apply(move)
+			build(n.Children[i], depth-1)
+			g.Undo()
+		}
+	}
+	build(tree.Root, depth)
+	return tree
+}
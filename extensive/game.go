@@ -0,0 +1,138 @@
+// Package extensive represents games as trees of decisions, chance moves
+// and outcomes, reads and writes them in Gambit's .efg format and finds
+// subgame perfect equilibria.
+package extensive
+
+// Chance is the player number of chance nodes. Players are numbered from 1
+// as in Gambit.
+const Chance = 0
+
+type Game struct {
+	Title   string
+	Comment string
+	Players []string
+	Root    *Node
+
+	// Last information set number given to each player
+	numbers map[int]int
+}
+
+// A game whose root is, so far, a terminal node
+func New(title string, players ...string) *Game {
+	return &Game{Title: title, Players: players, Root: &Node{}}
+}
+
+// Decision points a player can't tell apart. Every node in the set has
+// these actions.
+type InfoSet struct {
+	Player  int
+	Number  int // unique among the player's sets
+	Name    string
+	Actions []string
+
+	// Probability of each action at a chance node
+	Probs []float64
+
+	Nodes []*Node
+}
+
+// Payoffs to each player, added up along the path to a terminal node
+type Outcome struct {
+	Name    string
+	Payoffs []float64
+}
+
+type Node struct {
+	Name string
+
+	// nil for terminal nodes
+	InfoSet  *InfoSet
+	Children []*Node
+	Parent   *Node
+
+	// May be nil
+	Outcome *Outcome
+}
+
+func (n *Node) Terminal() bool {
+	return n.InfoSet == nil
+}
+
+// The player to move, Chance, or -1 at a terminal node
+func (n *Node) Player() int {
+	if n.InfoSet == nil {
+		return -1
+	}
+	return n.InfoSet.Player
+}
+
+// A new information set for player with the given actions
+func (g *Game) NewInfoSet(player int, name string, actions ...string) *InfoSet {
+	if g.numbers == nil {
+		g.numbers = make(map[int]int)
+	}
+	g.numbers[player]++
+	return &InfoSet{Player: player, Number: g.numbers[player], Name: name, Actions: actions}
+}
+
+// A new chance move taking each action with the matching probability
+func (g *Game) NewChance(name string, actions []string, probs []float64) *InfoSet {
+	set := g.NewInfoSet(Chance, name, actions...)
+	set.Probs = probs
+	return set
+}
+
+// Makes a terminal node a decision node in infoSet, giving it a child per
+// action
+func (n *Node) Expand(infoSet *InfoSet) {
+	n.InfoSet = infoSet
+	infoSet.Nodes = append(infoSet.Nodes, n)
+	n.Children = nil
+	for range infoSet.Actions {
+		n.Children = append(n.Children, &Node{Parent: n})
+	}
+}
+
+// Ends the game at a terminal node with these payoffs
+func (n *Node) SetPayoffs(payoffs ...float64) {
+	n.Outcome = &Outcome{Payoffs: payoffs}
+}
+
+// Every node, parents before children and children in order
+func (g *Game) Nodes() []*Node {
+	var nodes []*Node
+	var walk func(n *Node)
+	walk = func(n *Node) {
+		nodes = append(nodes, n)
+		for _, child := range n.Children {
+			walk(child)
+		}
+	}
+	if g.Root != nil {
+		walk(g.Root)
+	}
+	return nodes
+}
+
+// Every information set in the order its first node is reached
+func (g *Game) InfoSets() []*InfoSet {
+	var sets []*InfoSet
+	seen := make(map[*InfoSet]bool)
+	for _, n := range g.Nodes() {
+		if n.InfoSet != nil && !seen[n.InfoSet] {
+			seen[n.InfoSet] = true
+			sets = append(sets, n.InfoSet)
+		}
+	}
+	return sets
+}
+
+// Adds the outcome's payoffs to values
+func (n *Node) addOutcome(values []float64) {
+	if n.Outcome == nil {
+		return
+	}
+	for i, v := range n.Outcome.Payoffs {
+		values[i] += v
+	}
+}
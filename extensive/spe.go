@@ -0,0 +1,312 @@
+package extensive
+
+import (
+	"FinalProject/normalform"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// A behavior strategy for every player: the probability of each action at
+// each information set
+type Profile map[*InfoSet][]float64
+
+// Largest normal form built for a subgame, in pure strategies per player
+const maxStrategies = 4096
+
+// Finds a subgame perfect equilibrium by backward induction over subgames.
+// A subgame where every decision has its own information set is solved
+// one node at a time, taking the first of equal actions. Any other
+// subgame, once the subgames inside it are solved, becomes a normal form
+// game between two players and is solved with normalform.MixedNash. Returns
+// the profile and each player's expected payoff.
+func (g *Game) SubgamePerfect() (Profile, []float64, error) {
+	s := &solver{game: g, profile: make(Profile)}
+	s.findSubgames()
+	values, err := s.solve(g.Root)
+	if err != nil {
+		return nil, nil, err
+	}
+	return s.profile, values, nil
+}
+
+type solver struct {
+	game    *Game
+	profile Profile
+
+	// Nodes where a subgame starts
+	subgame map[*Node]bool
+}
+
+// Marks the nodes whose information sets don't reach outside their own
+// subtree
+func (s *solver) findSubgames() {
+	nodes := s.game.Nodes()
+	index := make(map[*Node]int, len(nodes))
+	for i, n := range nodes {
+		index[n] = i
+	}
+
+	// Range of preorder positions each information set covers
+	low := make(map[*InfoSet]int)
+	high := make(map[*InfoSet]int)
+	for i, n := range nodes {
+		if n.InfoSet == nil {
+			continue
+		}
+		if _, ok := low[n.InfoSet]; !ok {
+			low[n.InfoSet] = i
+		}
+		high[n.InfoSet] = i
+	}
+
+	s.subgame = make(map[*Node]bool)
+	var walk func(n *Node) (int, int, int)
+	walk = func(n *Node) (int, int, int) {
+		first := index[n]
+		end := first + 1
+		lo, hi := first, first
+		if n.InfoSet != nil {
+			lo, hi = low[n.InfoSet], high[n.InfoSet]
+		}
+		for _, child := range n.Children {
+			childLo, childHi, childEnd := walk(child)
+			lo, hi, end = min(lo, childLo), max(hi, childHi), childEnd
+		}
+		s.subgame[n] = lo >= first && hi < end
+		return lo, hi, end
+	}
+	walk(s.game.Root)
+}
+
+func (s *solver) solve(n *Node) ([]float64, error) {
+	values := make([]float64, len(s.game.Players))
+	if n.Terminal() {
+		n.addOutcome(values)
+		return values, nil
+	}
+
+	perfect := len(n.InfoSet.Nodes) == 1
+	for _, child := range n.Children {
+		perfect = perfect && s.subgame[child]
+	}
+	if !perfect {
+		return s.solveNormalForm(n)
+	}
+
+	children := make([][]float64, len(n.Children))
+	for i, child := range n.Children {
+		var err error
+		if children[i], err = s.solve(child); err != nil {
+			return nil, err
+		}
+	}
+
+	if n.Player() == Chance {
+		for i, child := range children {
+			for p, v := range child {
+				values[p] += n.InfoSet.Probs[i] * v
+			}
+		}
+	} else {
+		p := n.Player() - 1
+		best := 0
+		for i, child := range children {
+			if child[p] > children[best][p] {
+				best = i
+			}
+		}
+		copy(values, children[best])
+
+		probs := make([]float64, len(n.Children))
+		probs[best] = 1
+		s.profile[n.InfoSet] = probs
+	}
+
+	n.addOutcome(values)
+	return values, nil
+}
+
+// Solves the subgame at root, whose inner subgames are solved first, as a
+// normal form game
+func (s *solver) solveNormalForm(root *Node) ([]float64, error) {
+	if len(s.game.Players) != 2 {
+		return nil, fmt.Errorf("extensive: subgames with imperfect information need two players, not %d", len(s.game.Players))
+	}
+
+	// Solve the subgames hanging off this one and find each player's sets
+	frontier := make(map[*Node][]float64)
+	var sets [2][]*InfoSet
+	seen := make(map[*InfoSet]bool)
+	var walk func(n *Node) error
+	walk = func(n *Node) error {
+		if n != root && (n.Terminal() || s.subgame[n]) {
+			values, err := s.solve(n)
+			frontier[n] = values
+			return err
+		}
+		if p := n.Player(); p != Chance && !seen[n.InfoSet] {
+			seen[n.InfoSet] = true
+			sets[p-1] = append(sets[p-1], n.InfoSet)
+		}
+		for _, child := range n.Children {
+			if err := walk(child); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := walk(root); err != nil {
+		return nil, err
+	}
+
+	// A pure strategy picks an action at each of the player's sets
+	var strategies [2][][]int
+	for p := range strategies {
+		count := 1
+		for _, set := range sets[p] {
+			count *= len(set.Actions)
+			if count > maxStrategies {
+				return nil, fmt.Errorf("extensive: subgame has over %d pure strategies for player %d", maxStrategies, p+1)
+			}
+		}
+		for i := 0; i < count; i++ {
+			choice := make([]int, len(sets[p]))
+			rest := i
+			for k := len(sets[p]) - 1; k >= 0; k-- {
+				choice[k] = rest % len(sets[p][k].Actions)
+				rest /= len(sets[p][k].Actions)
+			}
+			strategies[p] = append(strategies[p], choice)
+		}
+	}
+
+	var payoffs [2][][]float64
+	for _, rowChoice := range strategies[0] {
+		var rowPayoffs, colPayoffs []float64
+		for _, colChoice := range strategies[1] {
+			profile := make(Profile)
+			for p, choice := range [2][]int{rowChoice, colChoice} {
+				for k, set := range sets[p] {
+					probs := make([]float64, len(set.Actions))
+					probs[choice[k]] = 1
+					profile[set] = probs
+				}
+			}
+			values := s.expected(root, root, profile, frontier)
+			rowPayoffs = append(rowPayoffs, values[0])
+			colPayoffs = append(colPayoffs, values[1])
+		}
+		payoffs[0] = append(payoffs[0], rowPayoffs)
+		payoffs[1] = append(payoffs[1], colPayoffs)
+	}
+
+	nf, err := normalform.New(payoffs[0], payoffs[1])
+	if err != nil {
+		return nil, err
+	}
+	eq, err := nf.MixedNash()
+	if err != nil {
+		return nil, err
+	}
+
+	// Each set's behavior is how often the mixed strategy takes each action
+	// there, counting only the pure strategies whose own earlier moves lead
+	// to the set. Sets the mix never reaches keep the overall frequencies.
+	for p, mix := range [2][]float64{eq.Row, eq.Col} {
+		for k, set := range sets[p] {
+			probs := make([]float64, len(set.Actions))
+			reached := make([]float64, len(set.Actions))
+			mass := 0.0
+			for i, choice := range strategies[p] {
+				probs[choice[k]] += mix[i]
+				if reaches(root, set, sets[p], choice) {
+					reached[choice[k]] += mix[i]
+					mass += mix[i]
+				}
+			}
+			if mass > 0 {
+				for a := range reached {
+					probs[a] = reached[a] / mass
+				}
+			}
+			s.profile[set] = probs
+		}
+	}
+	return s.expected(root, root, s.profile, frontier), nil
+}
+
+// Whether the pure strategy choice, picking an action at each of own,
+// leaves some node of set reachable below root
+func reaches(root *Node, set *InfoSet, own []*InfoSet, choice []int) bool {
+	for _, n := range set.Nodes {
+		consistent := true
+		for child := n; child != root && consistent; child = child.Parent {
+			parent := child.Parent
+			if parent == nil {
+				consistent = false
+				break
+			}
+			for k, ownSet := range own {
+				if parent.InfoSet == ownSet && parent.Children[choice[k]] != child {
+					consistent = false
+				}
+			}
+		}
+		if consistent {
+			return true
+		}
+	}
+	return false
+}
+
+// Expected payoffs below n when the players follow profile, stopping at the
+// solved frontier
+func (s *solver) expected(root, n *Node, profile Profile, frontier map[*Node][]float64) []float64 {
+	if n != root {
+		if values, ok := frontier[n]; ok {
+			return values
+		}
+	}
+
+	values := make([]float64, len(s.game.Players))
+	probs := profile[n.InfoSet]
+	if n.Player() == Chance {
+		probs = n.InfoSet.Probs
+	}
+	for i, child := range n.Children {
+		if probs[i] == 0 {
+			continue
+		}
+		for p, v := range s.expected(root, child, profile, frontier) {
+			values[p] += probs[i] * v
+		}
+	}
+	n.addOutcome(values)
+	return values
+}
+
+// Writes each player's behavior at every information set
+func WriteProfile(w io.Writer, g *Game, profile Profile) error {
+	for _, set := range g.InfoSets() {
+		probs, ok := profile[set]
+		if !ok || set.Player == Chance {
+			continue
+		}
+
+		var parts []string
+		for i, action := range set.Actions {
+			if probs[i] > 1e-9 {
+				parts = append(parts, fmt.Sprintf("%s %.4g", action, probs[i]))
+			}
+		}
+		name := set.Name
+		if name == "" {
+			name = fmt.Sprintf("#%d", set.Number)
+		}
+		if _, err := fmt.Fprintf(w, "%s at %s: %s\n", g.Players[set.Player-1], name, strings.Join(parts, ", ")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
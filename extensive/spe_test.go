@@ -0,0 +1,201 @@
+package extensive
+
+import (
+	"FinalProject/games"
+	"bytes"
+	"math"
+	"strings"
+	"testing"
+)
+
+func near(a, b float64) bool {
+	return math.Abs(a-b) < 1e-6
+}
+
+func TestEntryGame(t *testing.T) {
+	g := mustParse(t, entryEFG)
+	profile, values, err := g.SubgamePerfect()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The threat to fight isn't credible
+	if profile[g.Root.InfoSet][1] != 1 || profile[g.Root.Children[1].InfoSet][1] != 1 {
+		t.Errorf("Should enter and accommodate, got %v", profile)
+	}
+	if values[0] != 1 || values[1] != 1 {
+		t.Errorf("Should pay 1, 1, got %v", values)
+	}
+
+	var buf bytes.Buffer
+	WriteProfile(&buf, g, profile)
+	if !strings.Contains(buf.String(), "Incumbent at #1: Accommodate 1") {
+		t.Errorf("Should write the profile:\n%s", buf.String())
+	}
+}
+
+// Four stage centipede: each player can take the larger pile or pass and
+// let it grow. Backward induction takes at once.
+func TestCentipede(t *testing.T) {
+	g := New("Centipede", "1", "2")
+	payoffs := [][]float64{{1, 0}, {0, 2}, {3, 1}, {2, 4}, {5, 3}}
+
+	n := g.Root
+	for stage := 0; stage < 4; stage++ {
+		n.Expand(g.NewInfoSet(stage%2+1, "", "Take", "Pass"))
+		n.Children[0].SetPayoffs(payoffs[stage]...)
+		n = n.Children[1]
+	}
+	n.SetPayoffs(payoffs[4]...)
+
+	profile, values, err := g.SubgamePerfect()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, set := range g.InfoSets() {
+		if profile[set][0] != 1 {
+			t.Errorf("Should take at every stage, got %v at %d", profile[set], set.Number)
+		}
+	}
+	if values[0] != 1 || values[1] != 0 {
+		t.Errorf("Should end at once, got %v", values)
+	}
+}
+
+// Player 1 can stay out for 2 each or play a prisoner's dilemma. Its
+// equilibrium, mutual defection, pays less, so 1 stays out.
+func TestSimultaneousSubgame(t *testing.T) {
+	g := New("Outside option", "1", "2")
+	g.Root.Expand(g.NewInfoSet(1, "start", "Out", "In"))
+	g.Root.Children[0].SetPayoffs(2, 2)
+
+	in := g.Root.Children[1]
+	in.Expand(g.NewInfoSet(1, "pd", "C", "D"))
+	second := g.NewInfoSet(2, "pd", "C", "D")
+	pd := [2][2][]float64{{{3, 3}, {0, 5}}, {{5, 0}, {1, 1}}}
+	for i, n := range in.Children {
+		n.Expand(second)
+		for j, leaf := range n.Children {
+			leaf.SetPayoffs(pd[i][j]...)
+		}
+	}
+
+	profile, values, err := g.SubgamePerfect()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if profile[in.InfoSet][1] != 1 || profile[second][1] != 1 || profile[g.Root.InfoSet][0] != 1 {
+		t.Errorf("Should defect in the subgame and stay out, got %v", profile)
+	}
+	if values[0] != 2 || values[1] != 2 {
+		t.Errorf("Should pay 2, 2, got %v", values)
+	}
+}
+
+// Matching pennies after a fair coin that both see. The subgames are
+// solved mixed.
+func TestMixedSubgames(t *testing.T) {
+	g := New("Pennies", "1", "2")
+	g.Root.Expand(g.NewChance("coin", []string{"Heads", "Tails"}, []float64{0.5, 0.5}))
+
+	for _, n := range g.Root.Children {
+		n.Expand(g.NewInfoSet(1, "", "H", "T"))
+		second := g.NewInfoSet(2, "", "H", "T")
+		for i, child := range n.Children {
+			child.Expand(second)
+			for j, leaf := range child.Children {
+				if i == j {
+					leaf.SetPayoffs(1, -1)
+				} else {
+					leaf.SetPayoffs(-1, 1)
+				}
+			}
+		}
+	}
+
+	profile, values, err := g.SubgamePerfect()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, set := range g.InfoSets()[1:] {
+		if !near(profile[set][0], 0.5) {
+			t.Errorf("Should mix evenly, got %v", profile[set])
+		}
+	}
+	if !near(values[0], 0) {
+		t.Errorf("Should be worth 0, got %v", values)
+	}
+}
+
+func TestMovingTwiceInSubgame(t *testing.T) {
+	// 1 picks a side and, on the left, moves again before 2 guesses which
+	// side it was. The second move only matters once 1 has gone left.
+	g := New("Second move", "1", "2")
+	g.Root.Expand(g.NewInfoSet(1, "side", "Left", "Right"))
+	guess := g.NewInfoSet(2, "guess", "Left", "Right")
+
+	left := g.Root.Children[0]
+	left.Expand(g.NewInfoSet(1, "again", "Blunder", "Hold"))
+	for i, n := range left.Children {
+		n.Expand(guess)
+		if i == 0 {
+			n.Children[0].SetPayoffs(-5, 5)
+			n.Children[1].SetPayoffs(-5, 5)
+		} else {
+			n.Children[0].SetPayoffs(-1, 1)
+			n.Children[1].SetPayoffs(1, -1)
+		}
+	}
+	right := g.Root.Children[1]
+	right.Expand(guess)
+	right.Children[0].SetPayoffs(1, -1)
+	right.Children[1].SetPayoffs(-1, 1)
+
+	profile, values, err := g.SubgamePerfect()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !near(profile[left.InfoSet][1], 1) {
+		t.Errorf("Having gone left, 1 should always hold, got %v", profile[left.InfoSet])
+	}
+	if !near(profile[g.Root.InfoSet][0], 0.5) || !near(profile[guess][0], 0.5) {
+		t.Errorf("Should mix evenly, got %v and %v", profile[g.Root.InfoSet], profile[guess])
+	}
+	if !near(values[0], 0) {
+		t.Errorf("Should be worth 0, got %v", values)
+	}
+}
+
+func TestFromGame(t *testing.T) {
+	ttt := games.NewTicTacToe()
+
+	// X has two in the top row; O must block
+	for _, move := range []int{0, 4, 1} {
+		ttt.Apply(move)
+	}
+
+	before := ttt.Hash()
+	tree := FromGame(ttt, 2, [2]string{"X", "O"}, nil)
+	profile, values, err := tree.SubgamePerfect()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if block := profile[tree.Root.InfoSet]; tree.Root.InfoSet.Actions[indexOf(block, 1)] != "2" {
+		t.Errorf("O should block in cell 2, got %v", block)
+	}
+	if values[1] == -games.WinValue {
+		t.Errorf("O shouldn't lose, got %v", values)
+	}
+	if ttt.Hash() != before {
+		t.Error("Should leave the game as it was")
+	}
+}
+
+func indexOf(probs []float64, v float64) int {
+	for i, p := range probs {
+		if p == v {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,62 @@
+package game
+
+import "time"
+
+// The result of searching a position
+type Analysis struct {
+	// Best column, or -1 if the game is over
+	Move int
+
+	// Minimax value of the position for the side to move
+	Value int
+
+	// Principal variation, starting with Move
+	Line []int
+
+	// Number of layers searched
+	Depth int
+}
+
+// Searches the board to the player's depth without changing it
+func (player *SmartPlayer) Analyze(board *Board) Analysis {
+	c4 := &ConnectFour{Board: board.DuplicateBoard(), Eval: player.leafValue}
+	value, line := Search(c4, player.NumLayers, player.decay())
+
+	if len(line) == 0 {
+		return Analysis{Move: -1, Value: player.leafValue(board, player.Piece), Depth: player.NumLayers}
+	}
+	return Analysis{Move: line[0], Value: -value, Line: line, Depth: player.NumLayers}
+}
+
+// Searches the board for the side to move to the given depth
+func Analyze(board *Board, depth int) Analysis {
+	return NewSmartPlayer(board.WhoseTurn, depth).Analyze(board)
+}
+
+// Searches one layer deeper at a time until maxDepth is reached or the next
+// layer is unlikely to finish within limit. Always searches at least one
+// layer.
+func AnalyzeTimed(board *Board, maxDepth int, limit time.Duration) Analysis {
+	return AnalyzeDeepening(board, maxDepth, limit, nil)
+}
+
+// Like AnalyzeTimed, but calls report after each layer is searched. A limit
+// of 0 means no time limit.
+func AnalyzeDeepening(board *Board, maxDepth int, limit time.Duration, report func(Analysis)) Analysis {
+	start := time.Now()
+	var analysis Analysis
+
+	for depth := 1; depth <= maxDepth; depth++ {
+		// Each layer multiplies the tree by up to NumCols
+		elapsed := time.Since(start)
+		if depth > 1 && limit > 0 && elapsed*NumCols > limit {
+			break
+		}
+
+		analysis = Analyze(board, depth)
+		if report != nil {
+			report(analysis)
+		}
+	}
+	return analysis
+}
@@ -0,0 +1,73 @@
+package game
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAnalyzeFindsWin(t *testing.T) {
+	board := NewBoard()
+	board.PlayMoves([]int{0, 1, 0, 1, 0, 1})
+
+	analysis := Analyze(board, 2)
+
+	if analysis.Move != 0 {
+		t.Errorf("X should complete the column, not play %d", analysis.Move)
+	}
+
+	if analysis.Value <= 0 {
+		t.Errorf("A won position should have a positive value, not %d", analysis.Value)
+	}
+
+	if len(analysis.Line) != 1 || analysis.Line[0] != 0 {
+		t.Errorf("Line should end at the winning move: %v", analysis.Line)
+	}
+}
+
+func TestAnalyzeLineLength(t *testing.T) {
+	board := NewBoard()
+	analysis := Analyze(board, 3)
+
+	if len(analysis.Line) != 3 || analysis.Line[0] != analysis.Move {
+		t.Errorf("Line should cover every layer searched and start with the move: %v", analysis.Line)
+	}
+
+	if board.CalcPlayerValue('X') != 0 {
+		t.Error("Analyze should not change the board")
+	}
+}
+
+func TestAnalyzeFinishedGame(t *testing.T) {
+	board := NewBoard()
+	board.PlayMoves([]int{0, 1, 0, 1, 0, 1, 0})
+
+	if analysis := Analyze(board, 3); analysis.Move != -1 {
+		t.Errorf("There is no move once the game is over, got %d", analysis.Move)
+	}
+}
+
+func TestAnalyzeTimed(t *testing.T) {
+	board := NewBoard()
+	analysis := AnalyzeTimed(board, 10, time.Millisecond)
+
+	if analysis.Depth < 1 || analysis.Depth > 3 {
+		t.Errorf("Search with a tiny time limit should stop early, reached depth %d", analysis.Depth)
+	}
+}
+
+func TestAnalyzeDeepeningReports(t *testing.T) {
+	board := NewBoard()
+
+	var depths []int
+	analysis := AnalyzeDeepening(board, 3, 0, func(a Analysis) {
+		depths = append(depths, a.Depth)
+	})
+
+	if len(depths) != 3 || depths[0] != 1 || depths[2] != 3 {
+		t.Errorf("Should report every layer in order: %v", depths)
+	}
+
+	if analysis.Depth != 3 {
+		t.Errorf("Without a time limit the search should reach max depth, got %d", analysis.Depth)
+	}
+}
@@ -2,6 +2,8 @@ package game
 
 import (
 	"fmt"
+	"io"
+	"os"
 	"strings"
 )
 
@@ -25,18 +27,18 @@ var ConfigValues = map[string]int{
 }
 
 const NumCols = 7
-const numRows = 6
+const NumRows = 6
 const numDiags = 12
 
 type Board struct {
-	board [NumCols][numRows]byte
+	board [NumCols][NumRows]byte
 	WhoseTurn int
 	ValidMoves [NumCols]bool
 	Winner byte
 }
 
 func NewBoard() *Board {
-	var b [NumCols][numRows]byte
+	var b [NumCols][NumRows]byte
 
 	// Initialize all tiles to empty
 	for c, _ := range b {
@@ -52,7 +54,7 @@ func NewBoard() *Board {
 }
 
 func (oldBoard *Board) DuplicateBoard() *Board {
-	var b [NumCols][numRows]byte
+	var b [NumCols][NumRows]byte
 
 	// Initialize all tiles to old board tiles
 	for c, _ := range b {
@@ -77,7 +79,7 @@ func (board *Board) MakeMove(col int) {
 			board.board[col][i] = Tokens[board.WhoseTurn]
 
 			// If column is full, set it as invalid move
-			if (i == numRows - 1) {
+			if (i == NumRows - 1) {
 				board.ValidMoves[col] = false;
 			}
 			break
@@ -92,6 +94,21 @@ func (board *Board) IsValidMove(col int) bool {
 	return board.ValidMoves[col]
 }
 
+// Returns the token at the given column and row, where row 0 is the bottom
+func (board *Board) At(col, row int) byte {
+	return board.board[col][row]
+}
+
+// Returns the number of tokens in a column
+func (board *Board) Height(col int) int {
+	for i, val := range board.board[col] {
+		if val == ' ' {
+			return i
+		}
+	}
+	return NumRows
+}
+
 // Takes in a function used to calculate the value of a configuration of tokens
 // Returns total board valuation
 func (board *Board) checkBoardValue(valueFunction func(string) int) int {
@@ -99,7 +116,7 @@ func (board *Board) checkBoardValue(valueFunction func(string) int) int {
 
 	// Check each column
 	for _, col := range board.board {
-		val := valueFunction(string(col[:numRows]))
+		val := valueFunction(string(col[:NumRows]))
 		if val == int(^uint(0)  >> 1) || val == -int(^uint(0)  >> 1) {
 			return val
 		}
@@ -107,7 +124,7 @@ func (board *Board) checkBoardValue(valueFunction func(string) int) int {
 	}
 
 	// Check each row
-	for i := 0; i < numRows; i++ {
+	for i := 0; i < NumRows; i++ {
 		rowSlice := make([]byte, NumCols)
 
 		for j, col := range board.board {
@@ -156,15 +173,28 @@ func (board *Board) checkBoardValue(valueFunction func(string) int) int {
 }
 
 func (board *Board) CalcPlayerValue(token byte) int {
-	// checkSectionValue always returns value for player X
+	return board.CalcPlayerValueWith(token, ConfigValues)
+}
+
+// Like CalcPlayerValue, but with config in place of ConfigValues
+func (board *Board) CalcPlayerValueWith(token byte, config map[string]int) int {
+	sectionValue := func(s string) int {
+		return board.sectionValueWith(s, config)
+	}
+
+	// sectionValueWith always returns value for player X
 	if token == Tokens[0] {
-		return board.checkBoardValue(board.checkSectionValue)	
+		return board.checkBoardValue(sectionValue)	
 	} else {
-		return -board.checkBoardValue(board.checkSectionValue)
+		return -board.checkBoardValue(sectionValue)
 	}
 }
 
 func (board *Board) checkSectionValue(s string) int {
+	return board.sectionValueWith(s, ConfigValues)
+}
+
+func (board *Board) sectionValueWith(s string, config map[string]int) int {
 	sectionValue := 0
 
 	for tokenIdx, val := range Tokens {
@@ -175,7 +205,7 @@ func (board *Board) checkSectionValue(s string) int {
 
 		// Check for win
 		if strings.Contains(strings.Replace(s, string(val), "T", -1), "TTTT") {
-			return mul * ConfigValues["TTTT"]
+			return mul * config["TTTT"]
 		}
 
 		// Find idx of first token
@@ -203,7 +233,7 @@ func (board *Board) checkSectionValue(s string) int {
 					tokenString = strings.Replace(tokenString, string(val), "T", -1)
 					
 					// Don't need to check if key exists because 0 will be returned if it doesn't
-					sectionValue += mul * ConfigValues[tokenString]
+					sectionValue += mul * config[tokenString]
 
 					// Reset token string and set i to next token
 					tokenString = ""
@@ -226,7 +256,7 @@ func (board *Board) checkSectionValue(s string) int {
 			tokenString = strings.Replace(tokenString, string(val), "T", -1)
 			
 			// Don't need to check if key exists because 0 will be returned if it doesn't
-			sectionValue += mul * ConfigValues[tokenString]
+			sectionValue += mul * config[tokenString]
 		}
 	}
 
@@ -250,6 +280,36 @@ func (board *Board) checkSectionWin(s string) int {
 	return didWin
 }
 
+// Returns the cells of a four in a row as {col, row} pairs, or nil if there
+// isn't one. A line longer than four gives its first four cells.
+func (board *Board) WinningLine() [][2]int {
+	directions := [4][2]int{{1, 0}, {0, 1}, {1, 1}, {1, -1}}
+
+	for c := 0; c < NumCols; c++ {
+		for r := 0; r < NumRows; r++ {
+			token := board.board[c][r]
+			if token == ' ' {
+				continue
+			}
+
+			for _, d := range directions {
+				line := [][2]int{{c, r}}
+				for i := 1; i < 4; i++ {
+					col, row := c + d[0] * i, r + d[1] * i
+					if col < 0 || col >= NumCols || row < 0 || row >= NumRows || board.board[col][row] != token {
+						break
+					}
+					line = append(line, [2]int{col, row})
+				}
+				if len(line) == 4 {
+					return line
+				}
+			}
+		}
+	}
+	return nil
+}
+
 func (board *Board) CheckEndGame() bool {
 	isBoardFull := true
 	for _, col := range board.board {
@@ -267,17 +327,22 @@ func (board *Board) CheckEndGame() bool {
 }
 
 func (board *Board) Print() {
-	fmt.Println("\n  1   2   3   4   5   6   7")
-	fmt.Println("+---+---+---+---+---+---+---+")
+	board.Fprint(os.Stdout)
+}
+
+// Writes the board as plain text to w
+func (board *Board) Fprint(w io.Writer) {
+	fmt.Fprintln(w, "\n  1   2   3   4   5   6   7")
+	fmt.Fprintln(w, "+---+---+---+---+---+---+---+")
 
 	// Print each row
-	for r := numRows - 1; r >= 0; r-- {
+	for r := NumRows - 1; r >= 0; r-- {
 		for c := 0; c < NumCols; c++ {
-			fmt.Printf("| %c ", board.board[c][r])
+			fmt.Fprintf(w, "| %c ", board.board[c][r])
 		}
 
-		fmt.Println("|")
-		fmt.Println("+---+---+---+---+---+---+---+")
+		fmt.Fprintln(w, "|")
+		fmt.Fprintln(w, "+---+---+---+---+---+---+---+")
 	}
 
 }
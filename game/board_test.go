@@ -181,7 +181,7 @@ func TestCheckEndGameFull(t *testing.T) {
 	board := NewBoard()
 
 	// Fill board
-	for i := 0; i < NumCols * numRows; i++ {
+	for i := 0; i < NumCols * NumRows; i++ {
 		if i % 2 == 0 {
 			board.MakeMove(i % 7)	
 		} else {
@@ -318,3 +318,53 @@ func TestCalcPlayerValue(t *testing.T) {
 	}
 }
 
+
+func TestHeightAndAt(t *testing.T) {
+	board := NewBoard()
+
+	if board.Height(3) != 0 {
+		t.Error("Empty column should have height 0")
+	}
+
+	board.MakeMove(3)
+	board.MakeMove(3)
+
+	if board.Height(3) != 2 {
+		t.Errorf("Column should have height 2, not %d", board.Height(3))
+	}
+
+	if board.At(3, 0) != 'X' || board.At(3, 1) != 'O' || board.At(3, 2) != ' ' {
+		t.Error("At should return the tokens in the column from the bottom up")
+	}
+
+	for i := 0; i < 4; i++ {
+		board.MakeMove(3)
+	}
+
+	if board.Height(3) != NumRows {
+		t.Error("Full column should have height NumRows")
+	}
+}
+
+func TestWinningLine(t *testing.T) {
+	board := NewBoard()
+
+	if board.WinningLine() != nil {
+		t.Error("Empty board has no winning line")
+	}
+
+	// Diagonal for X from (0,0) to (3,3)
+	board.PlayMoves([]int{0, 1, 1, 2, 2, 3, 2, 3, 3, 6, 3})
+
+	line := board.WinningLine()
+	if len(line) != 4 {
+		t.Fatalf("Expected a diagonal, got %v", line)
+	}
+
+	for i, cell := range line {
+		if cell[0] != i || cell[1] != i {
+			t.Errorf("Diagonal should run from the bottom left: %v", line)
+			break
+		}
+	}
+}
@@ -0,0 +1,257 @@
+package game
+
+import (
+	"math"
+	"math/rand"
+	"time"
+)
+
+// Predicts how an opponent plays
+type OpponentModel interface {
+	// Returns the probability of each of moves, the legal moves in g
+	Probs(g Game, moves []int) []float64
+}
+
+// A model that learns from the moves it sees
+type Observer interface {
+	// Records that move was played in g
+	Observe(g Game, move int)
+}
+
+// Expects every legal move to be equally likely, as from RandomPlayer
+type UniformModel struct{}
+
+func (UniformModel) Probs(g Game, moves []int) []float64 {
+	probs := make([]float64, len(moves))
+	for i := range probs {
+		probs[i] = 1 / float64(len(moves))
+	}
+	return probs
+}
+
+// Temperature for SoftmaxModel when none is given, in Utility units
+const DefaultTemperature = 5
+
+// Expects good moves more often than bad ones: each move's probability
+// grows exponentially with the Utility it leaves the opponent one move
+// later. Low temperatures approach a minimax opponent and high ones a
+// random one.
+type SoftmaxModel struct {
+	Temperature float64
+}
+
+func (m SoftmaxModel) Probs(g Game, moves []int) []float64 {
+	temperature := m.Temperature
+	if temperature <= 0 {
+		temperature = DefaultTemperature
+	}
+
+	mover := g.CurrentPlayer()
+	values := make([]float64, len(moves))
+	best := math.Inf(-1)
+	for i, move := range moves {
+		g.Apply(move)
+		values[i] = float64(g.Utility(mover))
+		g.Undo()
+		best = math.Max(best, values[i])
+	}
+
+	probs := make([]float64, len(moves))
+	total := 0.0
+	for i, v := range values {
+		probs[i] = math.Exp((v - best) / temperature)
+		total += probs[i]
+	}
+	for i := range probs {
+		probs[i] /= total
+	}
+	return probs
+}
+
+// Counts the moves an opponent has played from each position. Positions
+// seen seldom or never lean on Fallback.
+type LearnedModel struct {
+	// Used where the counts run out. nil means UniformModel.
+	Fallback OpponentModel
+
+	// How many observed moves Fallback is worth
+	Prior float64
+
+	counts map[uint64]map[int]float64
+}
+
+func NewLearnedModel(fallback OpponentModel) *LearnedModel {
+	return &LearnedModel{Fallback: fallback, Prior: 1, counts: make(map[uint64]map[int]float64)}
+}
+
+func (m *LearnedModel) Observe(g Game, move int) {
+	hash := g.Hash()
+	if m.counts[hash] == nil {
+		m.counts[hash] = make(map[int]float64)
+	}
+	m.counts[hash][move]++
+}
+
+// Returns a copy that observes moves without touching m
+func (m *LearnedModel) Clone() *LearnedModel {
+	clone := *m
+	clone.counts = make(map[uint64]map[int]float64, len(m.counts))
+	for hash, counts := range m.counts {
+		clone.counts[hash] = make(map[int]float64, len(counts))
+		for move, n := range counts {
+			clone.counts[hash][move] = n
+		}
+	}
+	return &clone
+}
+
+// Observes every move of a recorded Connect Four game
+func (m *LearnedModel) LearnRecord(record *Record) {
+	board := NewBoard()
+	board.WhoseTurn = record.First
+	c4 := NewConnectFour(board)
+	for _, col := range record.Columns() {
+		m.Observe(c4, col)
+		c4.Apply(col)
+	}
+}
+
+// Number of positions with observed moves
+func (m *LearnedModel) Positions() int {
+	return len(m.counts)
+}
+
+func (m *LearnedModel) Probs(g Game, moves []int) []float64 {
+	fallback := m.Fallback
+	if fallback == nil {
+		fallback = UniformModel{}
+	}
+	probs := fallback.Probs(g, moves)
+
+	counts := m.counts[g.Hash()]
+	total := 0.0
+	for _, move := range moves {
+		total += counts[move]
+	}
+	if total == 0 {
+		return probs
+	}
+	for i, move := range moves {
+		probs[i] = (counts[move] + m.Prior*probs[i]) / (total + m.Prior)
+	}
+	return probs
+}
+
+// Searches like SmartPlayer but expects the opponent to play by Model
+// rather than to always find the best reply, so it sets traps a weak
+// opponent will fall into instead of giving up lines a perfect one would
+// refute.
+type ExpectimaxPlayer struct {
+	NumLayers int
+	Model     OpponentModel
+
+	// Leaf discount, squared each layer as in Search. 0 means Decay.
+	Decay float64
+
+	// Expected value of the most recent move
+	LastValue float64
+
+	// Board after our last move, to work out the opponent's reply
+	previous *Board
+}
+
+func NewExpectimaxPlayer(numLayers int, model OpponentModel) *ExpectimaxPlayer {
+	return &ExpectimaxPlayer{NumLayers: numLayers, Model: model}
+}
+
+// Picks the move with the highest expected Utility for the player to move
+func (player *ExpectimaxPlayer) ChooseMove(g Game) int {
+	source := rand.NewSource(time.Now().UnixNano())
+	rand := rand.New(source)
+
+	decay := player.Decay
+	if decay == 0 {
+		decay = Decay
+	}
+
+	me := g.CurrentPlayer()
+	best := -1
+	for _, move := range g.Moves() {
+		g.Apply(move)
+		value := player.expected(g, me, player.NumLayers-1, decay*decay)
+		g.Undo()
+
+		// If there are two equal values, choose randomly
+		if best < 0 || value > player.LastValue || (value == player.LastValue && rand.Intn(2) == 0) {
+			best = move
+			player.LastValue = value
+		}
+	}
+	return best
+}
+
+// Rounds LastValue to the scale of SmartPlayer's evaluations
+func (player *ExpectimaxPlayer) LastEvaluation() (int, bool) {
+	return int(math.Round(player.LastValue)), true
+}
+
+func (player *ExpectimaxPlayer) expected(g Game, me int, depth int, decay float64) float64 {
+	if depth <= 0 || g.Terminal() {
+		return float64(g.Utility(me)) * decay
+	}
+
+	moves := g.Moves()
+	if g.CurrentPlayer() == me {
+		value := math.Inf(-1)
+		for _, move := range moves {
+			g.Apply(move)
+			value = math.Max(value, player.expected(g, me, depth-1, decay*decay))
+			g.Undo()
+		}
+		return value
+	}
+
+	value := 0.0
+	for i, prob := range player.Model.Probs(g, moves) {
+		if prob == 0 {
+			continue
+		}
+		g.Apply(moves[i])
+		value += prob * player.expected(g, me, depth-1, decay*decay)
+		g.Undo()
+	}
+	return value
+}
+
+// Plays on a Connect Four board. A model that observes learns each reply
+// the opponent makes.
+func (player *ExpectimaxPlayer) MakeMove(board *Board) int {
+	if observer, ok := player.Model.(Observer); ok && player.previous != nil {
+		if col := addedColumn(player.previous, board); col >= 0 {
+			observer.Observe(NewConnectFour(player.previous), col)
+		}
+	}
+
+	move := player.ChooseMove(NewConnectFour(board.DuplicateBoard()))
+	board.MakeMove(move)
+	player.previous = board.DuplicateBoard()
+	return move
+}
+
+// The column holding the one token added between before and after, or -1
+func addedColumn(before, after *Board) int {
+	added := -1
+	for col := 0; col < NumCols; col++ {
+		switch after.Height(col) - before.Height(col) {
+		case 0:
+		case 1:
+			if added >= 0 {
+				return -1
+			}
+			added = col
+		default:
+			return -1
+		}
+	}
+	return added
+}
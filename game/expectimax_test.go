@@ -0,0 +1,121 @@
+package game
+
+import (
+	"math"
+	"testing"
+)
+
+func TestUniformModel(t *testing.T) {
+	c4 := NewConnectFour(NewBoard())
+	for _, p := range (UniformModel{}).Probs(c4, c4.Moves()) {
+		if math.Abs(p-1.0/7) > 1e-12 {
+			t.Errorf("Should spread evenly, got %v", p)
+		}
+	}
+}
+
+func TestSoftmaxModel(t *testing.T) {
+	// O to move can win in column 7
+	board := NewBoard()
+	board.PlayMoves([]int{0, 6, 1, 6, 3, 6, 0})
+	c4 := NewConnectFour(board)
+	before := board.Encode()
+
+	probs := SoftmaxModel{}.Probs(c4, c4.Moves())
+	if probs[6] < 0.99 {
+		t.Errorf("Should expect the win, got %v", probs)
+	}
+	if board.Encode() != before {
+		t.Error("Should leave the board alone")
+	}
+
+	c4 = NewConnectFour(NewBoard())
+	probs = SoftmaxModel{Temperature: 1e9}.Probs(c4, c4.Moves())
+	if math.Abs(probs[0]-probs[3]) > 1e-6 {
+		t.Errorf("A high temperature should be nearly uniform, got %v", probs)
+	}
+}
+
+func TestLearnedModel(t *testing.T) {
+	model := NewLearnedModel(nil)
+	c4 := NewConnectFour(NewBoard())
+	for i := 0; i < 9; i++ {
+		model.Observe(c4, 3)
+	}
+
+	probs := model.Probs(c4, c4.Moves())
+	if math.Abs(probs[3]-(9+1.0/7)/10) > 1e-12 || model.Positions() != 1 {
+		t.Errorf("Should mostly expect the center, got %v", probs)
+	}
+
+	c4.Apply(0)
+	if probs := model.Probs(c4, c4.Moves()); probs[3] != probs[0] {
+		t.Errorf("Should fall back where it has seen nothing, got %v", probs)
+	}
+
+	record := NewRecord("a", "b", 0)
+	for _, col := range []int{3, 3, 4} {
+		record.AddMove(col, nil, "")
+	}
+	model.LearnRecord(record)
+	if model.Positions() != 3 {
+		t.Errorf("Should learn a position per move, got %d", model.Positions())
+	}
+}
+
+func TestLearnedModelClone(t *testing.T) {
+	model := NewLearnedModel(nil)
+	c4 := NewConnectFour(NewBoard())
+	model.Observe(c4, 3)
+
+	clone := model.Clone()
+	clone.Observe(c4, 3)
+	c4.Apply(3)
+	clone.Observe(c4, 2)
+
+	if model.Positions() != 1 || clone.Positions() != 2 {
+		t.Errorf("Clone should learn on its own, got %d and %d positions", model.Positions(), clone.Positions())
+	}
+	c4.Undo()
+	if a, b := model.Probs(c4, c4.Moves()), clone.Probs(c4, c4.Moves()); a[3] >= b[3] {
+		t.Errorf("Only the clone should have seen the second center move: %v, %v", a, b)
+	}
+}
+
+func TestExpectimaxWins(t *testing.T) {
+	board := NewBoard()
+	board.PlayMoves([]int{0, 6, 1, 6, 2, 6})
+
+	player := NewExpectimaxPlayer(2, UniformModel{})
+	if move := player.MakeMove(board); move != 3 {
+		t.Errorf("Should take the win, got %d", move+1)
+	}
+}
+
+// The model sees O's move and learns from it
+func TestExpectimaxObserves(t *testing.T) {
+	model := NewLearnedModel(nil)
+	player := NewExpectimaxPlayer(1, model)
+
+	board := NewBoard()
+	player.MakeMove(board)
+	board.MakeMove(5)
+	player.MakeMove(board)
+	if model.Positions() != 1 {
+		t.Errorf("Should have observed one reply, got %d", model.Positions())
+	}
+}
+
+func TestExpectimaxBeatsRandom(t *testing.T) {
+	wins := 0
+	for i := 0; i < 10; i++ {
+		match := NewMatch(NewExpectimaxPlayer(2, UniformModel{}), &RandomPlayer{})
+		match.Board.WhoseTurn = i % 2
+		if match.Play() == Tokens[0] {
+			wins++
+		}
+	}
+	if wins < 7 {
+		t.Errorf("Should usually beat a random player, won %d of 10", wins)
+	}
+}
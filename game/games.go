@@ -0,0 +1,185 @@
+package game
+
+import (
+	"hash/fnv"
+	"math/rand"
+	"time"
+)
+
+// A two player game with alternating moves, numbered from 0. The search
+// players work on any Game; Connect Four is one of them.
+type Game interface {
+	// Legal moves in the current position
+	Moves() []int
+
+	// Makes a move for the current player
+	Apply(move int)
+
+	// Takes back the last move applied
+	Undo()
+
+	Terminal() bool
+
+	// Value of the position for a player: the payoff once the game is over,
+	// or an estimate of it before then
+	Utility(player int) int
+
+	// Index of the player to move, 0 or 1
+	CurrentPlayer() int
+
+	// Identifies the position, so equal positions have equal hashes
+	Hash() uint64
+}
+
+// Picks moves in any Game without changing it
+type GamePlayer interface {
+	ChooseMove(g Game) int
+}
+
+// Plays a game to the end and returns the winner's index, or -1 for a draw
+func PlayGame(g Game, players [2]GamePlayer) int {
+	for !g.Terminal() {
+		g.Apply(players[g.CurrentPlayer()].ChooseMove(g))
+	}
+
+	switch {
+	case g.Utility(0) > g.Utility(1):
+		return 0
+	case g.Utility(1) > g.Utility(0):
+		return 1
+	}
+	return -1
+}
+
+// Searches depth moves ahead by backward induction. Returns the value for
+// the player who moved into the current position and the best line of
+// play, choosing at random between equal moves. Leaves deeper in the tree
+// count for less: each layer squares decay.
+func Search(g Game, depth int, decay float64) (int, []int) {
+	return search(g, depth, decay, rand.New(rand.NewSource(time.Now().UnixNano())))
+}
+
+func search(g Game, depth int, decay float64, rng *rand.Rand) (int, []int) {
+	if depth == 0 || g.Terminal() {
+		return leafValue(g, decay), nil
+	}
+
+	// most negative value
+	value := -int(^uint(0) >> 1)
+	var line []int
+	for _, move := range g.Moves() {
+		g.Apply(move)
+		tmpVal, tmpLine := search(g, depth-1, decay*decay, rng)
+		g.Undo()
+
+		// If there are two equal values, choose randomly
+		if tmpVal > value || line == nil || (tmpVal == value && rng.Intn(2) == 0) {
+			value = tmpVal
+			line = append([]int{move}, tmpLine...)
+		}
+	}
+	return -value, line
+}
+
+// Decayed Utility for the player who moved into a leaf
+func leafValue(g Game, decay float64) int {
+	value := g.Utility(1 - g.CurrentPlayer())
+
+	// Scaling a win would overflow
+	if decay < 1 {
+		value = int(float64(value) * decay)
+	}
+	return value
+}
+
+// Connect Four as a Game. Moves are columns and players index Tokens.
+type ConnectFour struct {
+	Board *Board
+
+	// Values positions for the player using token. Defaults to
+	// CalcPlayerValue.
+	Eval func(board *Board, token byte) int
+
+	history []undoInfo
+}
+
+// What Undo needs to put back
+type undoInfo struct {
+	col, row int // row is -1 if the column was already full
+	valid    bool
+}
+
+// Plays on board, which is changed as moves are applied
+func NewConnectFour(board *Board) *ConnectFour {
+	return &ConnectFour{Board: board}
+}
+
+func (c4 *ConnectFour) Moves() []int {
+	var moves []int
+	for col := 0; col < NumCols; col++ {
+		if c4.Board.IsValidMove(col) {
+			moves = append(moves, col)
+		}
+	}
+	return moves
+}
+
+func (c4 *ConnectFour) Apply(move int) {
+	row := c4.Board.Height(move)
+	if row == NumRows {
+		row = -1
+	}
+	c4.history = append(c4.history, undoInfo{col: move, row: row, valid: c4.Board.ValidMoves[move]})
+	c4.Board.MakeMove(move)
+}
+
+func (c4 *ConnectFour) Undo() {
+	last := c4.history[len(c4.history)-1]
+	c4.history = c4.history[:len(c4.history)-1]
+
+	board := c4.Board
+	if last.row >= 0 {
+		board.board[last.col][last.row] = ' '
+	}
+	board.ValidMoves[last.col] = last.valid
+	board.WhoseTurn = (board.WhoseTurn + 1) % 2
+	board.Winner = ' '
+}
+
+func (c4 *ConnectFour) Terminal() bool {
+	return c4.Board.CheckEndGame()
+}
+
+func (c4 *ConnectFour) Utility(player int) int {
+	if c4.Eval != nil {
+		return c4.Eval(c4.Board, Tokens[player])
+	}
+	return c4.Board.CalcPlayerValue(Tokens[player])
+}
+
+func (c4 *ConnectFour) CurrentPlayer() int {
+	return c4.Board.WhoseTurn
+}
+
+func (c4 *ConnectFour) Hash() uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(c4.Board.Encode()))
+	return h.Sum64()
+}
+
+// Picks a legal move at random
+func (player *RandomPlayer) ChooseMove(g Game) int {
+	// Setup rand
+	source := rand.NewSource(time.Now().UnixNano())
+	rand := rand.New(source)
+
+	moves := g.Moves()
+	return moves[rand.Intn(len(moves))]
+}
+
+// Searches NumLayers moves ahead. Uses Decay, or Weights.Decay, but values
+// positions with the game's own Utility.
+func (player *SmartPlayer) ChooseMove(g Game) int {
+	_, line := Search(g, player.NumLayers, player.decay())
+	return line[0]
+}
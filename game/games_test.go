@@ -0,0 +1,88 @@
+package game
+
+import "testing"
+
+func TestConnectFourUndo(t *testing.T) {
+	board := NewBoard()
+	board.PlayMoves([]int{3, 3, 4})
+	before := *board
+
+	c4 := NewConnectFour(board)
+	hash := c4.Hash()
+	for _, move := range []int{4, 5, 5, 6} {
+		c4.Apply(move)
+	}
+	if c4.Hash() == hash {
+		t.Error("Should hash a different position differently")
+	}
+	for i := 0; i < 4; i++ {
+		c4.Undo()
+	}
+
+	if *board != before || c4.Hash() != hash {
+		t.Errorf("Should undo back to the start:\n%s", board.Encode())
+	}
+}
+
+func TestConnectFourUndoFullColumn(t *testing.T) {
+	board := NewBoard()
+	board.PlayMoves([]int{0, 0, 0, 0, 0, 0})
+	board.ValidMoves[0] = true
+	before := *board
+
+	c4 := NewConnectFour(board)
+	c4.Apply(0)
+	c4.Undo()
+	if *board != before {
+		t.Error("Should leave a full column alone")
+	}
+}
+
+func TestSearchFindsWin(t *testing.T) {
+	board := NewBoard()
+	board.PlayMoves([]int{0, 6, 1, 6, 2})
+
+	// O has to block
+	_, line := Search(NewConnectFour(board), 2, Decay)
+	if line[0] != 3 {
+		t.Errorf("Should block at column 4, got %d", line[0]+1)
+	}
+
+	board.MakeMove(6)
+	value, line := Search(NewConnectFour(board), 1, 1)
+	if line[0] != 3 || -value != ConfigValues["TTTT"] {
+		t.Errorf("Should win at column 4, got %d worth %d", line[0]+1, -value)
+	}
+}
+
+func TestChooseMove(t *testing.T) {
+	board := NewBoard()
+	board.PlayMoves([]int{0, 6, 1, 6, 2, 6})
+	c4 := NewConnectFour(board)
+
+	if move := NewSmartPlayer(0, 2).ChooseMove(c4); move != 3 {
+		t.Errorf("Should choose the win, got %d", move+1)
+	}
+	if board.Encode() != "......./......./......./......O/......O/XXX...O X" {
+		t.Errorf("Should leave the game alone: %s", board.Encode())
+	}
+
+	for i := 0; i < 20; i++ {
+		if move := (&RandomPlayer{}).ChooseMove(c4); !board.IsValidMove(move) {
+			t.Errorf("Should choose legal moves, got %d", move)
+		}
+	}
+}
+
+func TestPlayGame(t *testing.T) {
+	players := [2]GamePlayer{NewSmartPlayer(0, 2), &RandomPlayer{}}
+	c4 := NewConnectFour(NewBoard())
+
+	winner := PlayGame(c4, players)
+	if !c4.Terminal() {
+		t.Fatal("Should play to the end")
+	}
+	if winner >= 0 && c4.Board.Winner != Tokens[winner] {
+		t.Errorf("Should report winner %c, got %d", c4.Board.Winner, winner)
+	}
+}
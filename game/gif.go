@@ -0,0 +1,141 @@
+package game
+
+import (
+	"image"
+	"image/color"
+	"image/gif"
+	"io"
+)
+
+type GIFOptions struct {
+	// Width and height of each cell in pixels. Defaults to 40.
+	CellSize int
+
+	// Time each move stays on screen, in hundredths of a second. Defaults
+	// to 80. The last frame stays three times as long.
+	Delay int
+
+	// Show each token falling down its column
+	Animate bool
+
+	// Time each falling frame stays on screen. Defaults to 4.
+	DropDelay int
+}
+
+// Palette indexes
+const (
+	gifBoard = iota
+	gifEmpty
+	gifToken0
+	gifToken1
+	gifHighlight
+)
+
+var gifPalette = color.Palette{
+	color.RGBA{0x1f, 0x4f, 0xb4, 0xff},
+	color.RGBA{0xff, 0xff, 0xff, 0xff},
+	color.RGBA{0xd7, 0x26, 0x3d, 0xff},
+	color.RGBA{0xf2, 0xc2, 0x30, 0xff},
+	color.RGBA{0x11, 0x11, 0x11, 0xff},
+}
+
+// Writes the recorded game as an animated GIF with a frame for the empty
+// board and one for every move. The winning four, if any, is marked on the
+// last frame.
+func SaveGIF(w io.Writer, record *Record, opts GIFOptions) error {
+	if opts.CellSize <= 0 {
+		opts.CellSize = 40
+	}
+	if opts.Delay <= 0 {
+		opts.Delay = 80
+	}
+	if opts.DropDelay <= 0 {
+		opts.DropDelay = 4
+	}
+
+	anim := &gif.GIF{}
+	addFrame := func(img *image.Paletted, delay int) {
+		anim.Image = append(anim.Image, img)
+		anim.Delay = append(anim.Delay, delay)
+	}
+
+	board := NewBoard()
+	board.WhoseTurn = record.First
+	addFrame(drawGIFFrame(board, opts.CellSize, -1, -1, 0), opts.Delay)
+
+	for _, move := range record.Columns() {
+		if err := board.PlayMoves([]int{move}); err != nil {
+			return err
+		}
+
+		if opts.Animate {
+			row := board.Height(move) - 1
+			token := board.At(move, row)
+			for fall := NumRows - 1; fall > row; fall-- {
+				addFrame(drawGIFFrame(board, opts.CellSize, move, fall, token), opts.DropDelay)
+			}
+		}
+		addFrame(drawGIFFrame(board, opts.CellSize, -1, -1, 0), opts.Delay)
+	}
+
+	// Linger on the final position with the win marked
+	last := drawGIFFrame(board, opts.CellSize, -1, -1, 0)
+	for _, cell := range board.WinningLine() {
+		fillCircle(last, cell[0], NumRows-1-cell[1], opts.CellSize, opts.CellSize/6, gifHighlight)
+	}
+	anim.Image[len(anim.Image)-1] = last
+	anim.Delay[len(anim.Delay)-1] = opts.Delay * 3
+
+	return gif.EncodeAll(w, anim)
+}
+
+// Draws the board. If fallCol is on the board, fallToken is drawn at
+// fallRow and the top token of that column is hidden.
+func drawGIFFrame(board *Board, size int, fallCol int, fallRow int, fallToken byte) *image.Paletted {
+	img := image.NewPaletted(image.Rect(0, 0, NumCols*size, NumRows*size), gifPalette)
+	for i := range img.Pix {
+		img.Pix[i] = gifBoard
+	}
+
+	landing := -1
+	if fallCol >= 0 {
+		landing = board.Height(fallCol) - 1
+	}
+
+	for c := 0; c < NumCols; c++ {
+		for r := 0; r < NumRows; r++ {
+			token := board.board[c][r]
+			if c == fallCol {
+				if r == fallRow {
+					token = fallToken
+				} else if r == landing {
+					token = ' '
+				}
+			}
+
+			index := uint8(gifEmpty)
+			switch token {
+			case Tokens[0]:
+				index = gifToken0
+			case Tokens[1]:
+				index = gifToken1
+			}
+			fillCircle(img, c, NumRows-1-r, size, size*4/10, index)
+		}
+	}
+	return img
+}
+
+// Fills a circle centered in the cell at (col, y), where y counts down
+// from the top
+func fillCircle(img *image.Paletted, col int, y int, size int, radius int, index uint8) {
+	cx, cy := col*size+size/2, y*size+size/2
+	for py := cy - radius; py <= cy+radius; py++ {
+		for px := cx - radius; px <= cx+radius; px++ {
+			dx, dy := px-cx, py-cy
+			if dx*dx+dy*dy <= radius*radius {
+				img.SetColorIndex(px, py, index)
+			}
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package game
+
+import (
+	"bytes"
+	"image/gif"
+	"testing"
+)
+
+func TestSaveGIF(t *testing.T) {
+	record := NewRecord("a", "b", 0)
+	for _, col := range []int{0, 1, 0, 1, 0, 1, 0} {
+		record.AddMove(col, nil, "")
+	}
+
+	var buf bytes.Buffer
+	if err := SaveGIF(&buf, record, GIFOptions{CellSize: 20}); err != nil {
+		t.Fatal(err)
+	}
+
+	anim, err := gif.DecodeAll(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(anim.Image) != 8 {
+		t.Errorf("Expected the empty board plus a frame per move, got %d frames", len(anim.Image))
+	}
+
+	if bounds := anim.Image[0].Bounds(); bounds.Dx() != 140 || bounds.Dy() != 120 {
+		t.Errorf("Frames should be 7x6 cells of 20px, got %v", bounds)
+	}
+
+	// Bottom left cell is X's first token
+	if got := anim.Image[1].ColorIndexAt(10, 110); got != gifToken0 {
+		t.Errorf("First move should draw X in the bottom left, got color %d", got)
+	}
+
+	// The winning line is marked in the middle of the cells
+	last := anim.Image[len(anim.Image)-1]
+	if got := last.ColorIndexAt(10, 110); got != gifHighlight {
+		t.Errorf("Winning cells should be marked on the last frame, got color %d", got)
+	}
+
+	if anim.Delay[len(anim.Delay)-1] != 240 {
+		t.Errorf("Last frame should linger, delay %d", anim.Delay[len(anim.Delay)-1])
+	}
+}
+
+func TestSaveGIFAnimated(t *testing.T) {
+	record := NewRecord("a", "b", 0)
+	record.AddMove(3, nil, "")
+	record.AddMove(3, nil, "")
+
+	var buf bytes.Buffer
+	if err := SaveGIF(&buf, record, GIFOptions{CellSize: 10, Animate: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	anim, err := gif.DecodeAll(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Empty board, 5 falling frames and the landing for the first move,
+	// then 4 and the landing for the second
+	if len(anim.Image) != 1+6+5 {
+		t.Errorf("Unexpected number of frames %d", len(anim.Image))
+	}
+}
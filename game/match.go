@@ -0,0 +1,43 @@
+package game
+
+// A Match plays two players against each other on one board. The player at
+// Players[i] moves whenever it is board.WhoseTurn == i, so whoever goes first
+// is decided by the board's WhoseTurn when the match starts.
+type Match struct {
+	Board   *Board
+	Players [2]Player
+
+	// Columns played so far, in order
+	Moves []int
+
+	// Called after every move with the index of the player that moved
+	OnMove func(match *Match, playerIdx int, move int)
+}
+
+func NewMatch(p1 Player, p2 Player) *Match {
+	return &Match{Board: NewBoard(), Players: [2]Player{p1, p2}}
+}
+
+// Lets the player whose turn it is make one move. Returns false without
+// moving if the game is already over.
+func (match *Match) Step() bool {
+	if match.Board.CheckEndGame() {
+		return false
+	}
+
+	playerIdx := match.Board.WhoseTurn
+	move := match.Players[playerIdx].MakeMove(match.Board)
+	match.Moves = append(match.Moves, move)
+
+	if match.OnMove != nil {
+		match.OnMove(match, playerIdx, move)
+	}
+	return true
+}
+
+// Plays the match to the end and returns the winning token, or ' ' for a tie
+func (match *Match) Play() byte {
+	for match.Step() {
+	}
+	return match.Board.Winner
+}
@@ -0,0 +1,71 @@
+package game
+
+import "testing"
+
+// Plays a fixed list of columns in order
+type scriptedPlayer struct {
+	moves []int
+}
+
+func (player *scriptedPlayer) MakeMove(board *Board) int {
+	move := player.moves[0]
+	player.moves = player.moves[1:]
+	board.MakeMove(move)
+	return move
+}
+
+func TestMatchTurnOrder(t *testing.T) {
+	p1 := &scriptedPlayer{moves: []int{0, 0, 0, 0}}
+	p2 := &scriptedPlayer{moves: []int{1, 1, 1}}
+	match := NewMatch(p1, p2)
+
+	var movers []int
+	match.OnMove = func(match *Match, playerIdx int, move int) {
+		movers = append(movers, playerIdx)
+	}
+
+	if winner := match.Play(); winner != 'X' {
+		t.Errorf("Player X should have won with a column, not %c", winner)
+	}
+
+	if len(match.Moves) != 7 {
+		t.Errorf("Match should have recorded 7 moves, not %d", len(match.Moves))
+	}
+
+	for i, idx := range movers {
+		if idx != i%2 {
+			t.Errorf("Players should alternate starting with player 0: %v", movers)
+			break
+		}
+	}
+
+	if match.Step() {
+		t.Error("Step should not move after the game is over")
+	}
+}
+
+func TestMatchSecondPlayerFirst(t *testing.T) {
+	p1 := &scriptedPlayer{moves: []int{2}}
+	p2 := &scriptedPlayer{moves: []int{4}}
+	match := NewMatch(p1, p2)
+	match.Board.WhoseTurn = 1
+
+	match.Step()
+
+	if match.Board.board[4][0] != 'O' || match.Board.board[2][0] != ' ' {
+		t.Error("Player 2 should move first when the board starts on their turn")
+	}
+}
+
+func TestMatchRandomPlayers(t *testing.T) {
+	match := NewMatch(&RandomPlayer{}, &RandomPlayer{})
+	winner := match.Play()
+
+	if winner != ' ' && winner != 'X' && winner != 'O' {
+		t.Errorf("Unexpected winner %q", winner)
+	}
+
+	if !match.Board.CheckEndGame() {
+		t.Error("Play should only return once the game is over")
+	}
+}
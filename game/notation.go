@@ -0,0 +1,117 @@
+package game
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Returns the position as text: the rows from top to bottom separated by
+// '/', with '.' for empty cells, then a space and the token to move. The
+// empty board is "......./......./......./......./......./....... X".
+func (board *Board) Encode() string {
+	var sb strings.Builder
+	for r := NumRows - 1; r >= 0; r-- {
+		for c := 0; c < NumCols; c++ {
+			if board.board[c][r] == ' ' {
+				sb.WriteByte('.')
+			} else {
+				sb.WriteByte(board.board[c][r])
+			}
+		}
+		if r > 0 {
+			sb.WriteByte('/')
+		}
+	}
+	sb.WriteByte(' ')
+	sb.WriteByte(Tokens[board.WhoseTurn])
+	return sb.String()
+}
+
+// Parses a position written by Encode
+func DecodeBoard(s string) (*Board, error) {
+	cells, turn, ok := strings.Cut(strings.TrimSpace(s), " ")
+	rows := strings.Split(cells, "/")
+	if !ok || len(rows) != NumRows {
+		return nil, fmt.Errorf("game: bad board %q", s)
+	}
+
+	board := NewBoard()
+	switch turn {
+	case string(Tokens[0]):
+		board.WhoseTurn = 0
+	case string(Tokens[1]):
+		board.WhoseTurn = 1
+	default:
+		return nil, fmt.Errorf("game: bad turn %q in board", turn)
+	}
+
+	for i, row := range rows {
+		r := NumRows - 1 - i
+		if len(row) != NumCols {
+			return nil, fmt.Errorf("game: row %d of board has %d cells", i+1, len(row))
+		}
+		for c := 0; c < NumCols; c++ {
+			switch row[c] {
+			case '.':
+			case Tokens[0], Tokens[1]:
+				board.board[c][r] = row[c]
+			default:
+				return nil, fmt.Errorf("game: bad cell %q in board", row[c])
+			}
+		}
+	}
+
+	// Tokens can't float above an empty cell
+	for c := 0; c < NumCols; c++ {
+		for r := 1; r < NumRows; r++ {
+			if board.board[c][r] != ' ' && board.board[c][r-1] == ' ' {
+				return nil, fmt.Errorf("game: floating token in column %d", c+1)
+			}
+		}
+		board.ValidMoves[c] = board.board[c][NumRows-1] == ' '
+	}
+
+	board.checkForWin()
+	return board, nil
+}
+
+// Parses a move string listing the columns played, 1-7, such as "4453".
+// The returned moves are 0-indexed.
+func ParseMoves(s string) ([]int, error) {
+	s = strings.TrimSpace(s)
+	moves := make([]int, 0, len(s))
+	for i := 0; i < len(s); i++ {
+		if s[i] < '1' || s[i] >= '1'+NumCols {
+			return nil, fmt.Errorf("game: bad column %q in moves %q", s[i], s)
+		}
+		moves = append(moves, int(s[i]-'1'))
+	}
+	return moves, nil
+}
+
+// Writes 0-indexed moves as a move string
+func FormatMoves(moves []int) string {
+	b := make([]byte, len(moves))
+	for i, move := range moves {
+		b[i] = byte('1' + move)
+	}
+	return string(b)
+}
+
+// Plays moves in order. Stops with an error at a full column or a move made
+// after the game has ended.
+func (board *Board) PlayMoves(moves []int) error {
+	for i, move := range moves {
+		if board.CheckEndGame() {
+			return fmt.Errorf("game: move %d played after the game ended", i+1)
+		}
+		if move < 0 || move >= NumCols || !board.IsValidMove(move) {
+			return fmt.Errorf("game: move %d is illegal (column %d)", i+1, move+1)
+		}
+		board.MakeMove(move)
+	}
+
+	// Sets Winner if the last move ended the game
+	board.CheckEndGame()
+	return nil
+}
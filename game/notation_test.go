@@ -0,0 +1,100 @@
+package game
+
+import "testing"
+
+func TestEncodeEmptyBoard(t *testing.T) {
+	board := NewBoard()
+
+	if s := board.Encode(); s != "......./......./......./......./......./....... X" {
+		t.Errorf("Unexpected encoding of empty board: %q", s)
+	}
+}
+
+func TestEncodeDecodeRoundTrip(t *testing.T) {
+	board := NewBoard()
+	for _, move := range []int{3, 3, 4, 2, 6, 6, 6, 6, 6, 6} {
+		board.MakeMove(move)
+	}
+
+	decoded, err := DecodeBoard(board.Encode())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if *decoded != *board {
+		t.Errorf("Decoded board doesn't match:\n%s\n%s", board.Encode(), decoded.Encode())
+	}
+
+	if decoded.IsValidMove(6) {
+		t.Error("Full column should not be a valid move after decoding")
+	}
+}
+
+func TestDecodeBoardWinner(t *testing.T) {
+	board, err := DecodeBoard("......./......./......./......./OOO..../XXXX... O")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if board.Winner != 'X' {
+		t.Error("Decoding should set the winner")
+	}
+}
+
+func TestDecodeBadBoards(t *testing.T) {
+	bad := []string{
+		"",
+		"......./......./......./......./....... X",
+		"......./......./......./......./......./....... Z",
+		"......./......./......./......./......./...Q... X",
+		"......./......./......./......./...X.../....... O",
+	}
+
+	for _, s := range bad {
+		if _, err := DecodeBoard(s); err == nil {
+			t.Errorf("Expected error decoding %q", s)
+		}
+	}
+}
+
+func TestParseFormatMoves(t *testing.T) {
+	moves, err := ParseMoves("4453")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(moves) != 4 || moves[0] != 3 || moves[2] != 4 || moves[3] != 2 {
+		t.Errorf("Moves should be 0-indexed columns: %v", moves)
+	}
+
+	if s := FormatMoves(moves); s != "4453" {
+		t.Errorf("Formatting should give back the move string, not %q", s)
+	}
+
+	for _, bad := range []string{"408", "12a", "-1"} {
+		if _, err := ParseMoves(bad); err == nil {
+			t.Errorf("Expected error parsing %q", bad)
+		}
+	}
+}
+
+func TestPlayMoves(t *testing.T) {
+	board := NewBoard()
+	if err := board.PlayMoves([]int{0, 1, 0, 1, 0, 1, 0}); err != nil {
+		t.Fatal(err)
+	}
+
+	if board.Winner != 'X' {
+		t.Error("X should have won with four in the first column")
+	}
+
+	board = NewBoard()
+	if err := board.PlayMoves([]int{0, 1, 0, 1, 0, 1, 0, 1}); err == nil {
+		t.Error("Moves after the end of the game should be rejected")
+	}
+
+	board = NewBoard()
+	if err := board.PlayMoves([]int{2, 2, 2, 2, 2, 2, 2}); err == nil {
+		t.Error("Moves into a full column should be rejected")
+	}
+}
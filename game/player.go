@@ -3,8 +3,6 @@ package game
 import "github.com/twmb/algoimpl/go/graph"
 import (
     "bufio"
-	"math/rand"
-	"time"
     "fmt"
     "os"
     "strconv"
@@ -17,20 +15,19 @@ type Player interface {
 	MakeMove(board *Board) int
 }
 
+// Players that can say how good they thought their last move was, for
+// spectators and game records
+type Evaluating interface {
+	// Value of the last move for the player who made it, or false if the
+	// move wasn't searched
+	LastEvaluation() (int, bool)
+}
+
 type RandomPlayer struct {
 }
 
 func (player *RandomPlayer) MakeMove(board *Board) int {
-	// Setup rand
-	source := rand.NewSource(time.Now().UnixNano())
-    rand := rand.New(source)
-    
-    // Random between 0 and 6
-    move := rand.Intn(7)
-    for !board.IsValidMove(move) {
-    	move = rand.Intn(7)
-    }
-
+    move := player.ChooseMove(NewConnectFour(board))
     board.MakeMove(move)
 
 	return move
@@ -56,12 +53,39 @@ func (player *HumanPlayer) MakeMove(board *Board) int {
     // User move will be 1-indexed. We want 0 indexed
     board.MakeMove(move - 1)
 
-    return move
+    return move - 1
+}
+
+// Knows the exact value of some positions, such as a tablebase
+type EndgameProbe interface {
+    // Returns the value of the board for the side to move, and whether the
+    // board was known
+    Probe(board *Board) (int, bool)
+}
+
+// Scores positions at the leaves of the search in place of CalcPlayerValue,
+// such as a learned evaluation
+type Evaluator interface {
+    // Returns the value of the board for the player using token
+    Evaluate(board *Board, token byte) int
 }
 
 type SmartPlayer struct {
     Piece byte
     NumLayers int
+
+    // Consulted at the leaves of the search before CalcPlayerValue. May be
+    // nil.
+    Endgame EndgameProbe
+
+    // Evaluation weights in place of ConfigValues and Decay. May be nil.
+    Weights *Weights
+
+    // Evaluation in place of CalcPlayerValue. May be nil.
+    Evaluator Evaluator
+
+    // Search behind the most recent move
+    Last Analysis
 }
 
 func NewSmartPlayer(playerIdx int, numLayers int) *SmartPlayer {
@@ -69,47 +93,43 @@ func NewSmartPlayer(playerIdx int, numLayers int) *SmartPlayer {
 }
 
 func (player *SmartPlayer) MakeMove(board *Board) int {
-    g, startNode, _ := buildMoveTree(player.NumLayers, board, player.Piece)
-
-    _, arr := backwardsInduct(g, startNode, player.Piece, board, Decay)
-
-    board.MakeMove(arr[0])
-    return arr[0]
-}
-
-func backwardsInduct(g *graph.Graph, startNode *graph.Node, token byte, originalBoard *Board, decay float64) (int, []int) {
-    if len(g.Neighbors(*startNode)) > 0 {
-        // most negative value
-        value := -int(^uint(0)  >> 1)
-        idx := 0
-        //fmt.Printf("Token: %c ", token)
-        for i, node := range g.Neighbors(*startNode) {
-            tmpVal, _ := backwardsInduct(g, &node, nextToken(token), originalBoard, decay * decay)
-          //  fmt.Printf("%d, ", tmpVal)
-            if tmpVal > value {
-                value = tmpVal
-                idx = i
-            // If there are two equal values, choose randomly
-            } else if tmpVal == value {
-                source := rand.NewSource(time.Now().UnixNano())
-                rand := rand.New(source)
-                
-                // Random between 0 and 1
-                tmp := rand.Intn(2)
-                if tmp == 0 {
-                    value = tmpVal
-                    idx = i
-                }
+    player.Last = player.Analyze(board)
+    move := player.Last.Move
+
+    board.MakeMove(move)
+    return move
+}
+
+func (player *SmartPlayer) LastEvaluation() (int, bool) {
+    return player.Last.Value, true
+}
+
+// Value of a leaf for the player using token, exact if the endgame probe
+// knows it
+func (player *SmartPlayer) leafValue(board *Board, token byte) int {
+    if player.Endgame != nil {
+        if val, ok := player.Endgame.Probe(board); ok {
+            if Tokens[board.WhoseTurn] != token {
+                return -val
             }
+            return val
         }
-        //fmt.Printf("\n")
-  
-        chosenMoveList := (*g.Neighbors(*startNode)[idx].Value).([]int)
-        return -value, chosenMoveList
-    } else {
-        val := buildBoardFromMoveList((*startNode.Value).([]int), originalBoard).CalcPlayerValue(nextToken(token))
-        return int(float64(val) * decay), (*startNode.Value).([]int)
     }
+
+    if player.Evaluator != nil {
+        return player.Evaluator.Evaluate(board, token)
+    }
+    if player.Weights != nil {
+        return board.CalcPlayerValueWith(token, player.Weights.Config)
+    }
+    return board.CalcPlayerValue(token)
+}
+
+func (player *SmartPlayer) decay() float64 {
+    if player.Weights != nil {
+        return player.Weights.Decay
+    }
+    return Decay
 }
 
 func nextToken(token byte) byte {
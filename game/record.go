@@ -0,0 +1,316 @@
+package game
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Game records are text in the style of chess PGN: header lines, a blank
+// line, then the moves.
+//
+//	[X "smart:5"]
+//	[O "human"]
+//	[Date "2026.10.19"]
+//	[Rules "7x6 four in a row"]
+//	[First "X"]
+//	[Result "X"]
+//
+//	1. 4 {[%eval 3] center} 4 2. 5 3 ... X
+//
+// Moves are columns 1-7 numbered in pairs. A comment in braces belongs to
+// the move before it and may hold the engine evaluation for the player who
+// made the move as [%eval N]. The record ends with the result: X, O, draw,
+// or * for a game still in progress.
+
+// The only rules this package plays
+const Rules = "7x6 four in a row"
+
+const dateFormat = "2006.01.02"
+
+// Written in place of a date that isn't known
+const unknownDate = "????.??.??"
+
+// Results of a recorded game
+const (
+	ResultDraw       = "draw"
+	ResultInProgress = "*"
+)
+
+type RecordedMove struct {
+	Column  int // 0-indexed
+	Comment string
+
+	// Evaluation for the player who made the move, if known
+	Eval *int
+}
+
+type Record struct {
+	Players [2]string
+	Date    time.Time
+	First   int
+	Result  string
+	Moves   []RecordedMove
+
+	// Any other headers, kept so they survive a load and save
+	Tags map[string]string
+}
+
+// Starts a record for a game that hasn't been played yet
+func NewRecord(xPlayer string, oPlayer string, first int) *Record {
+	return &Record{
+		Players: [2]string{xPlayer, oPlayer},
+		Date:    time.Now(),
+		First:   first,
+		Result:  ResultInProgress,
+		Tags:    make(map[string]string),
+	}
+}
+
+func (record *Record) AddMove(col int, eval *int, comment string) {
+	record.Moves = append(record.Moves, RecordedMove{Column: col, Eval: eval, Comment: comment})
+}
+
+// Returns the columns played, 0-indexed
+func (record *Record) Columns() []int {
+	cols := make([]int, len(record.Moves))
+	for i, move := range record.Moves {
+		cols[i] = move.Column
+	}
+	return cols
+}
+
+// Replays the first n moves (all of them if n is negative) and returns the
+// board
+func (record *Record) BoardAt(n int) (*Board, error) {
+	if n < 0 || n > len(record.Moves) {
+		n = len(record.Moves)
+	}
+
+	board := NewBoard()
+	board.WhoseTurn = record.First
+	if err := board.PlayMoves(record.Columns()[:n]); err != nil {
+		return nil, err
+	}
+	return board, nil
+}
+
+// Replays every move
+func (record *Record) Board() (*Board, error) {
+	return record.BoardAt(-1)
+}
+
+// Sets Result from the board after the last move
+func (record *Record) Finish(board *Board) {
+	// CheckEndGame is what sets Winner
+	over := board.CheckEndGame()
+
+	switch {
+	case board.Winner != ' ':
+		record.Result = string(board.Winner)
+	case over:
+		record.Result = ResultDraw
+	default:
+		record.Result = ResultInProgress
+	}
+}
+
+func SaveGame(w io.Writer, record *Record) error {
+	bw := bufio.NewWriter(w)
+
+	header := func(name string, value string) {
+		fmt.Fprintf(bw, "[%s %s]\n", name, strconv.Quote(value))
+	}
+	header(string(Tokens[0]), record.Players[0])
+	header(string(Tokens[1]), record.Players[1])
+	if record.Date.IsZero() {
+		header("Date", unknownDate)
+	} else {
+		header("Date", record.Date.Format(dateFormat))
+	}
+	header("Rules", Rules)
+	header("First", string(Tokens[record.First]))
+	header("Result", record.Result)
+
+	names := make([]string, 0, len(record.Tags))
+	for name := range record.Tags {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		header(name, record.Tags[name])
+	}
+	fmt.Fprintln(bw)
+
+	// Keep lines short by breaking after each numbered pair
+	line := ""
+	for i, move := range record.Moves {
+		if i%2 == 0 {
+			if len(line) > 60 {
+				fmt.Fprintln(bw, strings.TrimSpace(line))
+				line = ""
+			}
+			line += fmt.Sprintf("%d. ", i/2+1)
+		}
+		line += strconv.Itoa(move.Column + 1)
+
+		comment := strings.TrimSpace(strings.NewReplacer("{", "(", "}", ")").Replace(move.Comment))
+		if move.Eval != nil {
+			comment = strings.TrimSpace(fmt.Sprintf("[%%eval %d] %s", *move.Eval, comment))
+		}
+		if comment != "" {
+			line += " {" + comment + "}"
+		}
+		line += " "
+	}
+	fmt.Fprintln(bw, strings.TrimSpace(line+record.Result))
+
+	return bw.Flush()
+}
+
+func LoadGame(r io.Reader) (*Record, error) {
+	record := &Record{Result: ResultInProgress, Tags: make(map[string]string)}
+	scanner := bufio.NewScanner(r)
+
+	// Headers
+	var body strings.Builder
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		if !strings.HasPrefix(line, "[") {
+			body.WriteString(line + "\n")
+			break
+		}
+
+		name, value, err := parseHeader(line)
+		if err != nil {
+			return nil, err
+		}
+
+		switch name {
+		case string(Tokens[0]):
+			record.Players[0] = value
+		case string(Tokens[1]):
+			record.Players[1] = value
+		case "Date":
+			if value == unknownDate {
+				continue
+			}
+			if record.Date, err = time.Parse(dateFormat, value); err != nil {
+				return nil, fmt.Errorf("game: bad date %q in record", value)
+			}
+		case "Rules":
+			if value != Rules {
+				return nil, fmt.Errorf("game: can't load a game played under rules %q", value)
+			}
+		case "First":
+			switch value {
+			case string(Tokens[0]):
+				record.First = 0
+			case string(Tokens[1]):
+				record.First = 1
+			default:
+				return nil, fmt.Errorf("game: bad first player %q in record", value)
+			}
+		case "Result":
+			record.Result = value
+		default:
+			record.Tags[name] = value
+		}
+	}
+
+	for scanner.Scan() {
+		body.WriteString(scanner.Text() + "\n")
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := record.parseMoves(body.String()); err != nil {
+		return nil, err
+	}
+
+	// Make sure the moves can actually be played
+	if _, err := record.Board(); err != nil {
+		return nil, err
+	}
+	return record, nil
+}
+
+func parseHeader(line string) (string, string, error) {
+	if !strings.HasSuffix(line, "]") {
+		return "", "", fmt.Errorf("game: bad header %q", line)
+	}
+
+	name, quoted, ok := strings.Cut(strings.TrimSpace(line[1:len(line)-1]), " ")
+	if !ok {
+		return "", "", fmt.Errorf("game: bad header %q", line)
+	}
+
+	value, err := strconv.Unquote(strings.TrimSpace(quoted))
+	if err != nil {
+		return "", "", fmt.Errorf("game: bad header value in %q", line)
+	}
+	return name, value, nil
+}
+
+func (record *Record) parseMoves(body string) error {
+	for len(body) > 0 {
+		body = strings.TrimLeft(body, " \t\r\n")
+		if body == "" {
+			break
+		}
+
+		// Comments belong to the move before them
+		if body[0] == '{' {
+			end := strings.IndexByte(body, '}')
+			if end < 0 {
+				return fmt.Errorf("game: unclosed comment in record")
+			}
+			if len(record.Moves) == 0 {
+				return fmt.Errorf("game: comment before the first move in record")
+			}
+			record.Moves[len(record.Moves)-1].setComment(body[1:end])
+			body = body[end+1:]
+			continue
+		}
+
+		end := strings.IndexAny(body, " \t\r\n{")
+		if end < 0 {
+			end = len(body)
+		}
+		token := body[:end]
+		body = body[end:]
+
+		switch {
+		case strings.HasSuffix(token, "."):
+			// Move number
+		case len(token) == 1 && token[0] >= '1' && token[0] < '1'+NumCols:
+			record.Moves = append(record.Moves, RecordedMove{Column: int(token[0] - '1')})
+		case token == string(Tokens[0]) || token == string(Tokens[1]) || token == ResultDraw || token == ResultInProgress:
+			record.Result = token
+		default:
+			return fmt.Errorf("game: unexpected %q in record moves", token)
+		}
+	}
+	return nil
+}
+
+func (move *RecordedMove) setComment(text string) {
+	text = strings.TrimSpace(text)
+	if strings.HasPrefix(text, "[%eval ") {
+		if end := strings.IndexByte(text, ']'); end > 0 {
+			if eval, err := strconv.Atoi(strings.TrimSpace(text[len("[%eval "):end])); err == nil {
+				move.Eval = &eval
+				text = strings.TrimSpace(text[end+1:])
+			}
+		}
+	}
+	move.Comment = text
+}
@@ -0,0 +1,98 @@
+package game
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSaveLoadRoundTrip(t *testing.T) {
+	record := NewRecord("smart:5", "human", 1)
+	record.Date = time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+	record.Tags["Event"] = "Class tournament"
+
+	eval := -7
+	record.AddMove(3, nil, "")
+	record.AddMove(3, &eval, "blocks {the} center")
+	record.AddMove(4, nil, "")
+
+	var buf bytes.Buffer
+	if err := SaveGame(&buf, record); err != nil {
+		t.Fatal(err)
+	}
+
+	text := buf.String()
+	for _, want := range []string{`[X "smart:5"]`, `[First "O"]`, `[Date "2026.10.19"]`, `[Event "Class tournament"]`, "1. 4 4 {[%eval -7] blocks (the) center} 2. 5 *"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("Saved game should contain %q:\n%s", want, text)
+		}
+	}
+
+	loaded, err := LoadGame(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if loaded.Players != record.Players || loaded.First != 1 || !loaded.Date.Equal(record.Date) || loaded.Tags["Event"] != "Class tournament" {
+		t.Errorf("Headers didn't survive: %+v", loaded)
+	}
+
+	if len(loaded.Moves) != 3 || loaded.Moves[1].Eval == nil || *loaded.Moves[1].Eval != -7 || loaded.Moves[1].Comment != "blocks (the) center" {
+		t.Errorf("Moves didn't survive: %+v", loaded.Moves)
+	}
+
+	board, err := loaded.Board()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if board.At(3, 0) != 'O' || board.At(3, 1) != 'X' || board.WhoseTurn != 0 {
+		t.Error("Board should be replayed starting with O")
+	}
+}
+
+func TestFinishResult(t *testing.T) {
+	record := NewRecord("a", "b", 0)
+	record.Date = time.Time{}
+	board := NewBoard()
+	for _, col := range []int{0, 1, 0, 1, 0, 1, 0} {
+		record.AddMove(col, nil, "")
+		board.MakeMove(col)
+	}
+
+	record.Finish(board)
+
+	if record.Result != "X" {
+		t.Errorf("X won, not %q", record.Result)
+	}
+
+	var buf bytes.Buffer
+	SaveGame(&buf, record)
+	loaded, err := LoadGame(&buf)
+	if err != nil || loaded.Result != "X" {
+		t.Errorf("Result should load back as X: %v %v", loaded, err)
+	}
+
+	if !strings.Contains(buf.String(), "????.??.??") && !loaded.Date.IsZero() {
+		t.Error("Unknown date should be saved as ????.??.?? and stay unknown")
+	}
+}
+
+func TestLoadBadRecords(t *testing.T) {
+	bad := []string{
+		"[Rules \"9x9 five in a row\"]\n\n1. 4 *",
+		"[First \"Z\"]\n\n*",
+		"[Date 2026]\n\n*",
+		"\n1. 8 *",
+		"\n{before} 1. 4 *",
+		"\n1. 4 {unclosed",
+		"\n1. 1 1 2. 1 1 3. 1 1 4. 1 *",
+	}
+
+	for _, text := range bad {
+		if _, err := LoadGame(strings.NewReader(text)); err == nil {
+			t.Errorf("Expected error loading %q", text)
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package game
+
+// Evaluations in a review are capped at this size so a won position can be
+// compared with an ordinary one without overflowing
+const ReviewCap = 10000
+
+// What the engine thinks of one move in a recorded game
+type PlyReview struct {
+	// 1-based ply and the 0-indexed column played
+	Ply    int
+	Column int
+	Player byte
+
+	// Value of the position for the mover before and after the move, capped
+	// at ReviewCap
+	Before int
+	After  int
+
+	// The engine's choice before the move, or -1 if it had none
+	Best int
+
+	// How much the move lost compared to the engine's expectation
+	Swing   int
+	Blunder bool
+}
+
+// Searches every position in the record to depth and flags the moves that
+// lost more than threshold
+func Review(record *Record, depth int, threshold int) ([]PlyReview, error) {
+	// Values[i] is the value for the side to move after i moves
+	values := make([]int, len(record.Moves)+1)
+	best := make([]int, len(record.Moves)+1)
+	players := make([]byte, len(record.Moves)+1)
+
+	for i := range values {
+		board, err := record.BoardAt(i)
+		if err != nil {
+			return nil, err
+		}
+
+		analysis := Analyze(board, depth)
+		values[i] = capValue(analysis.Value)
+		best[i] = analysis.Move
+		players[i] = Tokens[board.WhoseTurn]
+	}
+
+	reviews := make([]PlyReview, len(record.Moves))
+	for i, move := range record.Moves {
+		review := PlyReview{
+			Ply:    i + 1,
+			Column: move.Column,
+			Player: players[i],
+			Before: values[i],
+			After:  -values[i+1],
+			Best:   best[i],
+		}
+		review.Swing = review.Before - review.After
+		review.Blunder = review.Swing > threshold
+		reviews[i] = review
+	}
+	return reviews, nil
+}
+
+func capValue(value int) int {
+	return Max(-ReviewCap, Min(ReviewCap, value))
+}
@@ -0,0 +1,47 @@
+package game
+
+import "testing"
+
+func TestReviewFlagsMissedBlock(t *testing.T) {
+	record := NewRecord("a", "b", 0)
+
+	// X builds three in column 1 and O ignores it
+	for _, col := range []int{0, 6, 0, 6, 0, 5} {
+		record.AddMove(col, nil, "")
+	}
+
+	reviews, err := Review(record, 2, 1000)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(reviews) != 6 {
+		t.Fatalf("Expected a review for each move, got %d", len(reviews))
+	}
+
+	last := reviews[5]
+	if !last.Blunder || last.Player != 'O' || last.Best != 0 {
+		t.Errorf("O should have blocked column 1: %+v", last)
+	}
+
+	if last.After != -ReviewCap {
+		t.Errorf("After the blunder O is lost, value should be capped at %d, not %d", -ReviewCap, last.After)
+	}
+
+	for _, review := range reviews[:4] {
+		if review.Blunder {
+			t.Errorf("Early moves shouldn't be blunders: %+v", review)
+		}
+	}
+}
+
+func TestReviewBadRecord(t *testing.T) {
+	record := NewRecord("a", "b", 0)
+	for i := 0; i < 7; i++ {
+		record.AddMove(0, nil, "")
+	}
+
+	if _, err := Review(record, 1, 100); err == nil {
+		t.Error("Review should fail on a record with an illegal move")
+	}
+}
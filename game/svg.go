@@ -0,0 +1,92 @@
+package game
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+)
+
+// What RenderSVG draws besides the tokens
+type SVGOptions struct {
+	// Width and height of each cell in pixels. Defaults to 60.
+	CellSize int
+
+	// Ring the top token of LastMove's column
+	MarkLastMove bool
+	LastMove     int
+
+	// Draw a line through four in a row, if there is one
+	MarkWinningLine bool
+
+	// Put an arrow above the Suggestion column
+	MarkSuggestion bool
+	Suggestion     int
+}
+
+// Colors used for each entry of Tokens
+var SVGTokenColors = [2]string{"#d7263d", "#f2c230"}
+
+const (
+	svgBoardColor      = "#1f4fb4"
+	svgEmptyColor      = "#ffffff"
+	svgHighlightColor  = "#111111"
+	svgSuggestionColor = "#2a9d3f"
+)
+
+// Writes the position as a standalone SVG image
+func (board *Board) RenderSVG(w io.Writer, opts SVGOptions) error {
+	size := opts.CellSize
+	if size <= 0 {
+		size = 60
+	}
+	radius := size * 4 / 10
+
+	// One spare row on top for the suggestion arrow
+	top := size / 2
+	width, height := NumCols*size, NumRows*size+top
+
+	// Center of a cell in pixels
+	center := func(col, row int) (int, int) {
+		return col*size + size/2, top + (NumRows-1-row)*size + size/2
+	}
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d">`+"\n", width, height, width, height)
+	fmt.Fprintf(bw, `<rect x="0" y="%d" width="%d" height="%d" rx="%d" fill="%s"/>`+"\n", top, width, height-top, size/6, svgBoardColor)
+
+	for c := 0; c < NumCols; c++ {
+		for r := 0; r < NumRows; r++ {
+			fill := svgEmptyColor
+			for i, token := range Tokens {
+				if board.board[c][r] == token {
+					fill = SVGTokenColors[i]
+				}
+			}
+			x, y := center(c, r)
+			fmt.Fprintf(bw, `<circle cx="%d" cy="%d" r="%d" fill="%s"/>`+"\n", x, y, radius, fill)
+		}
+	}
+
+	if opts.MarkLastMove && opts.LastMove >= 0 && opts.LastMove < NumCols {
+		if row := board.Height(opts.LastMove) - 1; row >= 0 {
+			x, y := center(opts.LastMove, row)
+			fmt.Fprintf(bw, `<circle cx="%d" cy="%d" r="%d" fill="none" stroke="%s" stroke-width="%d"/>`+"\n", x, y, radius*6/10, svgHighlightColor, Max(1, size/20))
+		}
+	}
+
+	if opts.MarkWinningLine {
+		if line := board.WinningLine(); line != nil {
+			x1, y1 := center(line[0][0], line[0][1])
+			x2, y2 := center(line[3][0], line[3][1])
+			fmt.Fprintf(bw, `<line x1="%d" y1="%d" x2="%d" y2="%d" stroke="%s" stroke-width="%d" stroke-linecap="round" opacity="0.8"/>`+"\n", x1, y1, x2, y2, svgHighlightColor, Max(2, size/8))
+		}
+	}
+
+	if opts.MarkSuggestion && opts.Suggestion >= 0 && opts.Suggestion < NumCols {
+		x := opts.Suggestion*size + size/2
+		fmt.Fprintf(bw, `<polygon points="%d,%d %d,%d %d,%d" fill="%s"/>`+"\n", x-size/4, top/5, x+size/4, top/5, x, top-top/8, svgSuggestionColor)
+	}
+
+	fmt.Fprintln(bw, "</svg>")
+	return bw.Flush()
+}
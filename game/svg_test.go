@@ -0,0 +1,61 @@
+package game
+
+import (
+	"encoding/xml"
+	"io"
+	"strings"
+	"testing"
+)
+
+func renderSVG(t *testing.T, board *Board, opts SVGOptions) string {
+	t.Helper()
+	var sb strings.Builder
+	if err := board.RenderSVG(&sb, opts); err != nil {
+		t.Fatal(err)
+	}
+
+	// Must be well formed XML
+	dec := xml.NewDecoder(strings.NewReader(sb.String()))
+	for {
+		if _, err := dec.Token(); err == io.EOF {
+			break
+		} else if err != nil {
+			t.Fatalf("Bad SVG: %v\n%s", err, sb.String())
+		}
+	}
+	return sb.String()
+}
+
+func TestRenderSVGEmpty(t *testing.T) {
+	svg := renderSVG(t, NewBoard(), SVGOptions{})
+
+	if n := strings.Count(svg, "<circle"); n != NumCols*NumRows {
+		t.Errorf("Expected a circle per cell, got %d", n)
+	}
+
+	if !strings.Contains(svg, `width="420"`) || strings.Contains(svg, "<line") || strings.Contains(svg, "<polygon") {
+		t.Error("Default image should be 60px cells with no markers")
+	}
+}
+
+func TestRenderSVGMarkers(t *testing.T) {
+	board := NewBoard()
+	board.PlayMoves([]int{0, 1, 0, 1, 0, 1, 0})
+
+	svg := renderSVG(t, board, SVGOptions{
+		CellSize:        40,
+		MarkLastMove:    true,
+		LastMove:        0,
+		MarkWinningLine: true,
+		MarkSuggestion:  true,
+		Suggestion:      3,
+	})
+
+	if strings.Count(svg, SVGTokenColors[0]) != 4 || strings.Count(svg, SVGTokenColors[1]) != 3 {
+		t.Error("Each token should be drawn in its color")
+	}
+
+	if !strings.Contains(svg, `fill="none"`) || !strings.Contains(svg, "<line") || !strings.Contains(svg, "<polygon") {
+		t.Errorf("All markers should be drawn:\n%s", svg)
+	}
+}
@@ -0,0 +1,157 @@
+package game
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// A node of the tree SmartPlayer searches, with the value Search gives it
+type SearchNode struct {
+	// Column played to reach this node, or -1 at the root
+	Move int `json:"move"`
+
+	// Columns played from the root, 0-indexed
+	Moves []int `json:"moves"`
+
+	// Token that played Move, or the token not to move at the root
+	Player string `json:"player"`
+
+	// Minimax value for Player. Each node's value is minus the highest
+	// value among its children.
+	Value int `json:"value"`
+
+	// Whether the node is on the principal variation
+	PV bool `json:"pv"`
+
+	Children []*SearchNode `json:"children,omitempty"`
+}
+
+// Searches the board to depth layers for the side to move and returns the
+// whole tree. Of equal children the lowest column is taken as the principal
+// variation, where SmartPlayer would pick one at random.
+func SearchTree(board *Board, depth int) *SearchNode {
+	return NewSmartPlayer(board.WhoseTurn, depth).SearchTree(board)
+}
+
+// Like SearchTree, but values leaves with the player's evaluation and decay
+// so the tree shows the search behind the player's moves
+func (player *SmartPlayer) SearchTree(board *Board) *SearchNode {
+	c4 := &ConnectFour{Board: board.DuplicateBoard(), Eval: player.leafValue}
+
+	root := exportNode(c4, []int{}, player.NumLayers, player.decay())
+	root.Move = -1
+	root.PV = true
+	for node := root; len(node.Children) > 0; {
+		node = node.Children[node.best()]
+		node.PV = true
+	}
+	return root
+}
+
+// Searches like Search, keeping every node
+func exportNode(g Game, moves []int, depth int, decay float64) *SearchNode {
+	node := &SearchNode{Moves: moves, Player: string(Tokens[1-g.CurrentPlayer()])}
+	if len(moves) > 0 {
+		node.Move = moves[len(moves)-1]
+	}
+
+	if depth == 0 || g.Terminal() {
+		node.Value = leafValue(g, decay)
+		return node
+	}
+
+	for _, move := range g.Moves() {
+		g.Apply(move)
+		childMoves := append(append([]int{}, moves...), move)
+		node.Children = append(node.Children, exportNode(g, childMoves, depth-1, decay*decay))
+		g.Undo()
+	}
+	node.Value = -node.Children[node.best()].Value
+	return node
+}
+
+// Returns the index of the child with the highest value
+func (node *SearchNode) best() int {
+	best := 0
+	for i, child := range node.Children {
+		if child.Value > node.Children[best].Value || (child.Value == node.Children[best].Value && child.Move < node.Children[best].Move) {
+			best = i
+		}
+	}
+	return best
+}
+
+// Returns a copy of the tree without the nodes more than depth moves from
+// this one. Values are kept as searched.
+func (node *SearchNode) Prune(depth int) *SearchNode {
+	pruned := *node
+	pruned.Children = nil
+	if depth > 0 {
+		for _, child := range node.Children {
+			pruned.Children = append(pruned.Children, child.Prune(depth-1))
+		}
+	}
+	return &pruned
+}
+
+// Counts the nodes in the tree
+func (node *SearchNode) Size() int {
+	size := 1
+	for _, child := range node.Children {
+		size += child.Size()
+	}
+	return size
+}
+
+// Writes the tree as a Graphviz digraph. Each node shows its move and value,
+// and the principal variation is drawn in red.
+func WriteDOT(w io.Writer, root *SearchNode) error {
+	var sb strings.Builder
+	sb.WriteString("digraph search {\n")
+	sb.WriteString("\tnode [shape=box, fontname=\"Helvetica\"];\n")
+
+	id := 0
+	var write func(node *SearchNode) int
+	write = func(node *SearchNode) int {
+		nodeID := id
+		id++
+
+		label := fmt.Sprintf("%s %d\\n%s", node.Player, node.Move+1, formatTreeValue(node.Value))
+		if node.Move < 0 {
+			// Show the root from the side to move
+			label = fmt.Sprintf("%c to move\\n%s", nextToken(node.Player[0]), formatTreeValue(-node.Value))
+		}
+		style := ""
+		if node.PV {
+			style = ", color=red, penwidth=2"
+		}
+		fmt.Fprintf(&sb, "\tn%d [label=\"%s\"%s];\n", nodeID, label, style)
+
+		for _, child := range node.Children {
+			childID := write(child)
+			style := ""
+			if node.PV && child.PV {
+				style = " [color=red, penwidth=2]"
+			}
+			fmt.Fprintf(&sb, "\tn%d -> n%d%s;\n", nodeID, childID, style)
+		}
+		return nodeID
+	}
+	write(root)
+
+	sb.WriteString("}\n")
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// Wins are scored near the largest int, which is no use in a label
+func formatTreeValue(value int) string {
+	switch {
+	case value >= ReviewCap:
+		return "win"
+	case value <= -ReviewCap:
+		return "loss"
+	}
+	return fmt.Sprintf("%+d", value)
+}
@@ -0,0 +1,121 @@
+package game
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestSearchTreeMatchesAnalyze(t *testing.T) {
+	board := NewBoard()
+	board.PlayMoves([]int{3, 3, 2})
+
+	root := SearchTree(board, 3)
+	analysis := Analyze(board, 3)
+
+	if -root.Value != analysis.Value {
+		t.Errorf("Root value %d should match the analysis value %d", -root.Value, analysis.Value)
+	}
+
+	if len(root.Children) != NumCols {
+		t.Errorf("Root should have a child per column, got %d", len(root.Children))
+	}
+
+	if size := root.Size(); size != 1+7+49+343 {
+		t.Errorf("Unexpected tree size %d", size)
+	}
+
+	// Follow the principal variation
+	var line []int
+	for node := root; ; {
+		var next *SearchNode
+		for _, child := range node.Children {
+			if child.PV {
+				if next != nil {
+					t.Fatal("Only one child should be on the principal variation")
+				}
+				next = child
+			}
+		}
+		if next == nil {
+			break
+		}
+		line = append(line, next.Move)
+		node = next
+	}
+	if len(line) != 3 {
+		t.Errorf("Principal variation should reach the leaves: %v", line)
+	}
+}
+
+// The tree values leaves the way the player does
+func TestSearchTreeUsesPlayerWeights(t *testing.T) {
+	board := NewBoard()
+	board.PlayMoves([]int{3, 3, 2})
+
+	player := NewSmartPlayer(board.WhoseTurn, 2)
+	player.Weights = DefaultWeights()
+	player.Weights.Config[" TT "] = 40
+	player.Weights.Decay = 1
+
+	root := player.SearchTree(board)
+	if analysis := player.Analyze(board); -root.Value != analysis.Value {
+		t.Errorf("Root value %d should match the player's analysis %d", -root.Value, analysis.Value)
+	}
+	if -root.Value == -SearchTree(board, 2).Value {
+		t.Errorf("Weights should change the tree's values, both gave %d", -root.Value)
+	}
+}
+
+func TestSearchTreeWin(t *testing.T) {
+	board := NewBoard()
+	board.PlayMoves([]int{0, 1, 0, 1, 0, 1})
+
+	root := SearchTree(board, 2)
+	winning := root.Children[0]
+
+	if !winning.PV || winning.Player != "X" || len(winning.Children) != 0 {
+		t.Errorf("The winning move should be a leaf on the principal variation: %+v", winning)
+	}
+}
+
+func TestPrune(t *testing.T) {
+	root := SearchTree(NewBoard(), 3)
+	pruned := root.Prune(1)
+
+	if pruned.Size() != 1+NumCols {
+		t.Errorf("Pruned tree should have 8 nodes, got %d", pruned.Size())
+	}
+
+	if root.Size() != 1+7+49+343 {
+		t.Error("Pruning should not change the original tree")
+	}
+}
+
+func TestWriteDOT(t *testing.T) {
+	board := NewBoard()
+	board.PlayMoves([]int{0, 1, 0, 1, 0, 1})
+	root := SearchTree(board, 1)
+
+	var sb strings.Builder
+	if err := WriteDOT(&sb, root); err != nil {
+		t.Fatal(err)
+	}
+	dot := sb.String()
+
+	if !strings.HasPrefix(dot, "digraph search {") || !strings.HasSuffix(dot, "}\n") {
+		t.Errorf("Not a digraph:\n%s", dot)
+	}
+
+	if strings.Count(dot, "->") != NumCols {
+		t.Errorf("Expected an edge per move:\n%s", dot)
+	}
+
+	if !strings.Contains(dot, `n1 [label="X 1\nwin", color=red`) || !strings.Contains(dot, "n0 -> n1 [color=red") {
+		t.Errorf("Winning move should be labeled and on the principal variation:\n%s", dot)
+	}
+
+	if _, err := json.Marshal(root); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,121 @@
+package game
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// A set of evaluation weights: the pattern values of ConfigValues and the
+// Decay applied to deeper leaves.
+//
+// Weight files have one weight per line, with the pattern quoted:
+//
+//	decay 0.95
+//	" T " 2
+//	" TT " 5
+//
+// Patterns left out keep their ConfigValues weight. The winning pattern
+// "TTTT" is fixed and can't be set.
+type Weights struct {
+	Config map[string]int
+	Decay  float64
+}
+
+// Returns a copy of the current ConfigValues and Decay
+func DefaultWeights() *Weights {
+	weights := &Weights{Config: make(map[string]int, len(ConfigValues)), Decay: Decay}
+	for pattern, value := range ConfigValues {
+		weights.Config[pattern] = value
+	}
+	return weights
+}
+
+func (weights *Weights) Copy() *Weights {
+	c := &Weights{Config: make(map[string]int, len(weights.Config)), Decay: weights.Decay}
+	for pattern, value := range weights.Config {
+		c.Config[pattern] = value
+	}
+	return c
+}
+
+// Makes these the weights every player without its own uses
+func (weights *Weights) Apply() {
+	for pattern, value := range weights.Config {
+		ConfigValues[pattern] = value
+	}
+	Decay = weights.Decay
+}
+
+// Patterns that can be weighted, in a fixed order
+func WeightPatterns() []string {
+	var patterns []string
+	for pattern := range ConfigValues {
+		if pattern != "TTTT" {
+			patterns = append(patterns, pattern)
+		}
+	}
+	sort.Slice(patterns, func(i, j int) bool {
+		a, b := strings.TrimSpace(patterns[i]), strings.TrimSpace(patterns[j])
+		if len(a) != len(b) {
+			return len(a) < len(b)
+		}
+		return patterns[i] < patterns[j]
+	})
+	return patterns
+}
+
+func SaveWeights(w io.Writer, weights *Weights) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "decay %s\n", strconv.FormatFloat(weights.Decay, 'g', -1, 64))
+	for _, pattern := range WeightPatterns() {
+		fmt.Fprintf(bw, "%s %d\n", strconv.Quote(pattern), weights.Config[pattern])
+	}
+	return bw.Flush()
+}
+
+func LoadWeights(r io.Reader) (*Weights, error) {
+	weights := DefaultWeights()
+	scanner := bufio.NewScanner(r)
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if rest, ok := strings.CutPrefix(line, "decay "); ok {
+			decay, err := strconv.ParseFloat(strings.TrimSpace(rest), 64)
+			if err != nil || decay <= 0 || decay > 1 {
+				return nil, fmt.Errorf("game: line %d: decay must be in (0, 1]", lineNum)
+			}
+			weights.Decay = decay
+			continue
+		}
+
+		// The pattern is quoted since it has spaces in it
+		end := strings.LastIndexByte(line, '"')
+		if !strings.HasPrefix(line, `"`) || end <= 0 {
+			return nil, fmt.Errorf("game: line %d: want a quoted pattern and a weight", lineNum)
+		}
+		pattern, err := strconv.Unquote(line[:end+1])
+		if err != nil {
+			return nil, fmt.Errorf("game: line %d: bad pattern", lineNum)
+		}
+		if _, ok := ConfigValues[pattern]; !ok || pattern == "TTTT" {
+			return nil, fmt.Errorf("game: line %d: can't weight pattern %q", lineNum, pattern)
+		}
+
+		value, err := strconv.Atoi(strings.TrimSpace(line[end+1:]))
+		if err != nil {
+			return nil, fmt.Errorf("game: line %d: bad weight", lineNum)
+		}
+		weights.Config[pattern] = value
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return weights, nil
+}
@@ -0,0 +1,59 @@
+package game
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestWeightsRoundTrip(t *testing.T) {
+	weights := DefaultWeights()
+	weights.Config[" TT "] = 7
+	weights.Decay = 0.9
+
+	var sb strings.Builder
+	if err := SaveWeights(&sb, weights); err != nil {
+		t.Fatal(err)
+	}
+
+	if strings.Contains(sb.String(), "TTTT") {
+		t.Error("The winning pattern shouldn't be saved")
+	}
+
+	loaded, err := LoadWeights(strings.NewReader(sb.String()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if loaded.Config[" TT "] != 7 || loaded.Decay != 0.9 || loaded.Config["TTTT"] != ConfigValues["TTTT"] {
+		t.Errorf("Weights should survive a save and load:\n%s", sb.String())
+	}
+}
+
+func TestLoadWeightsErrors(t *testing.T) {
+	for _, text := range []string{`"TTTT" 1`, `"XX" 1`, `"T " one`, "decay 2", `T 1`} {
+		if _, err := LoadWeights(strings.NewReader(text)); err == nil {
+			t.Errorf("Should reject %q", text)
+		}
+	}
+}
+
+func TestPlayerWeights(t *testing.T) {
+	board := NewBoard()
+	board.PlayMoves([]int{3, 3, 2})
+
+	// With every pattern worth nothing, the evaluation is flat
+	weights := DefaultWeights()
+	for _, pattern := range WeightPatterns() {
+		weights.Config[pattern] = 0
+	}
+
+	player := NewSmartPlayer(board.WhoseTurn, 2)
+	player.Weights = weights
+	if value := player.Analyze(board).Value; value != 0 {
+		t.Errorf("Zero weights should value the position at 0, got %d", value)
+	}
+
+	if Analyze(board, 2).Value == 0 {
+		t.Error("Default weights should be unchanged")
+	}
+}
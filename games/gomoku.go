@@ -0,0 +1,184 @@
+package games
+
+import (
+	"math/rand"
+	"strings"
+)
+
+// Gomoku: players take turns placing stones on a Size x Size board and the
+// first to get Length in a row wins. Moves are cells, row*Size+col.
+type Gomoku struct {
+	Size   int
+	Length int
+
+	cells   []int8 // -1 for empty, otherwise the player
+	turn    int
+	winner  int
+	history []int
+	hash    uint64
+	zobrist [][2]uint64
+}
+
+// Standard Gomoku is NewGomoku(15, 5)
+func NewGomoku(size, length int) *Gomoku {
+	g := &Gomoku{
+		Size:    size,
+		Length:  length,
+		cells:   make([]int8, size*size),
+		winner:  -1,
+		zobrist: make([][2]uint64, size*size),
+	}
+
+	// Fixed seed, so equal positions hash the same in every game
+	rng := rand.New(rand.NewSource(int64(size)))
+	for i := range g.cells {
+		g.cells[i] = -1
+		g.zobrist[i] = [2]uint64{rng.Uint64(), rng.Uint64()}
+	}
+	return g
+}
+
+// Empty cells next to a stone, so the search only looks where the play is.
+// On an empty board, the center.
+func (g *Gomoku) Moves() []int {
+	if g.Terminal() {
+		return nil
+	}
+	if len(g.history) == 0 {
+		return []int{g.Size/2*g.Size + g.Size/2}
+	}
+
+	var moves []int
+	for cell, stone := range g.cells {
+		if stone < 0 && g.nearStone(cell) {
+			moves = append(moves, cell)
+		}
+	}
+	return moves
+}
+
+func (g *Gomoku) nearStone(cell int) bool {
+	row, col := cell/g.Size, cell%g.Size
+	for dr := -1; dr <= 1; dr++ {
+		for dc := -1; dc <= 1; dc++ {
+			r, c := row+dr, col+dc
+			if r >= 0 && r < g.Size && c >= 0 && c < g.Size && g.cells[r*g.Size+c] >= 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (g *Gomoku) Apply(move int) {
+	g.cells[move] = int8(g.turn)
+	g.hash ^= g.zobrist[move][g.turn]
+	g.history = append(g.history, move)
+	if g.run(move) >= g.Length {
+		g.winner = g.turn
+	}
+	g.turn = 1 - g.turn
+}
+
+func (g *Gomoku) Undo() {
+	move := g.history[len(g.history)-1]
+	g.history = g.history[:len(g.history)-1]
+	g.turn = 1 - g.turn
+	g.hash ^= g.zobrist[move][g.turn]
+	g.cells[move] = -1
+	g.winner = -1
+}
+
+var directions = [4][2]int{{0, 1}, {1, 0}, {1, 1}, {1, -1}}
+
+// Length of the longest line through cell of the stone on it
+func (g *Gomoku) run(cell int) int {
+	stone := g.cells[cell]
+	row, col := cell/g.Size, cell%g.Size
+
+	longest := 0
+	for _, d := range directions {
+		n := 1
+		for _, sign := range [2]int{1, -1} {
+			r, c := row+sign*d[0], col+sign*d[1]
+			for r >= 0 && r < g.Size && c >= 0 && c < g.Size && g.cells[r*g.Size+c] == stone {
+				n++
+				r, c = r+sign*d[0], c+sign*d[1]
+			}
+		}
+		if n > longest {
+			longest = n
+		}
+	}
+	return longest
+}
+
+func (g *Gomoku) Terminal() bool {
+	return g.winner >= 0 || len(g.history) == len(g.cells)
+}
+
+// WinValue for a win, minus that for a loss. Otherwise each window of
+// Length cells holding only one player's stones counts 4^stones for them,
+// capped below WinValue.
+func (g *Gomoku) Utility(player int) int {
+	switch g.winner {
+	case player:
+		return WinValue
+	case 1 - player:
+		return -WinValue
+	}
+
+	value := 0
+	for row := 0; row < g.Size; row++ {
+		for col := 0; col < g.Size; col++ {
+			for _, d := range directions {
+				endRow, endCol := row+d[0]*(g.Length-1), col+d[1]*(g.Length-1)
+				if endRow >= g.Size || endCol < 0 || endCol >= g.Size {
+					continue
+				}
+
+				var count [2]int
+				for i := 0; i < g.Length; i++ {
+					if stone := g.cells[(row+d[0]*i)*g.Size+col+d[1]*i]; stone >= 0 {
+						count[stone]++
+					}
+				}
+				switch {
+				case count[1-player] == 0 && count[player] > 0:
+					value += 1 << (2 * count[player])
+				case count[player] == 0 && count[1-player] > 0:
+					value -= 1 << (2 * count[1-player])
+				}
+			}
+		}
+	}
+
+	if value >= WinValue {
+		return WinValue - 1
+	}
+	if value <= -WinValue {
+		return -WinValue + 1
+	}
+	return value
+}
+
+func (g *Gomoku) CurrentPlayer() int {
+	return g.turn
+}
+
+// Zobrist hash of the stones
+func (g *Gomoku) Hash() uint64 {
+	return g.hash
+}
+
+// The board in rows of X, O and '.'
+func (g *Gomoku) String() string {
+	var sb strings.Builder
+	for i, cell := range g.cells {
+		sb.WriteByte(cellByte(cell))
+		if i%g.Size == g.Size-1 {
+			sb.WriteByte('\n')
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,70 @@
+package games
+
+import (
+	"FinalProject/game"
+	"testing"
+)
+
+func TestGomokuWin(t *testing.T) {
+	g := NewGomoku(9, 5)
+
+	// X along the diagonal, O on the row below
+	for i := 0; i < 4; i++ {
+		play(g, i*9+i, (i+1)*9+i)
+	}
+	if g.Terminal() {
+		t.Fatal("Should not be over at four")
+	}
+
+	g.Apply(4*9 + 4)
+	if !g.Terminal() || g.Utility(0) != WinValue {
+		t.Errorf("Should be won by X:\n%s", g)
+	}
+
+	g.Undo()
+	if g.Terminal() {
+		t.Error("Should undo the win")
+	}
+}
+
+func TestGomokuHash(t *testing.T) {
+	a, b := NewGomoku(9, 5), NewGomoku(9, 5)
+	play(a, 40, 41, 31)
+	play(b, 31, 41, 40)
+	if a.Hash() != b.Hash() {
+		t.Error("Should hash transpositions the same")
+	}
+
+	a.Undo()
+	if a.Hash() == b.Hash() || a.Hash() == NewGomoku(9, 5).Hash() {
+		t.Error("Should hash different positions differently")
+	}
+	a.Undo()
+	a.Undo()
+	if a.Hash() != NewGomoku(9, 5).Hash() {
+		t.Error("Should hash the empty board the same after undoing")
+	}
+}
+
+func TestGomokuMoves(t *testing.T) {
+	g := NewGomoku(9, 5)
+	if moves := g.Moves(); len(moves) != 1 || moves[0] != 40 {
+		t.Errorf("Should start in the center, got %v", moves)
+	}
+
+	g.Apply(0)
+	if moves := g.Moves(); len(moves) != 3 {
+		t.Errorf("Should only play next to stones, got %v", moves)
+	}
+}
+
+func TestGomokuBlocks(t *testing.T) {
+	g := NewGomoku(9, 5)
+
+	// X has four on the middle row, blocked on the left
+	play(g, 37, 36, 38, 8, 39, 72, 40)
+	smart := &game.SmartPlayer{NumLayers: 2, Weights: &game.Weights{Decay: 1}}
+	if move := smart.ChooseMove(g); move != 41 {
+		t.Errorf("Should block the four, got %d", move)
+	}
+}
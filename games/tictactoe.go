@@ -0,0 +1,137 @@
+// Package games holds games other than Connect Four for the search players
+// in package game.
+package games
+
+import (
+	"FinalProject/game"
+	"strings"
+)
+
+// Score of a won game. Positions before the end score less.
+const WinValue = 1000
+
+var lines = [8][3]int{
+	{0, 1, 2}, {3, 4, 5}, {6, 7, 8},
+	{0, 3, 6}, {1, 4, 7}, {2, 5, 8},
+	{0, 4, 8}, {2, 4, 6},
+}
+
+// Tic-Tac-Toe. Moves are cells 0-8, left to right and top to bottom, and
+// player 0 uses X.
+type TicTacToe struct {
+	cells   [9]int8 // -1 for empty, otherwise the player
+	turn    int
+	history []int
+}
+
+func NewTicTacToe() *TicTacToe {
+	t := &TicTacToe{}
+	for i := range t.cells {
+		t.cells[i] = -1
+	}
+	return t
+}
+
+func (t *TicTacToe) Moves() []int {
+	if t.winner() >= 0 {
+		return nil
+	}
+	var moves []int
+	for i, cell := range t.cells {
+		if cell < 0 {
+			moves = append(moves, i)
+		}
+	}
+	return moves
+}
+
+func (t *TicTacToe) Apply(move int) {
+	t.cells[move] = int8(t.turn)
+	t.turn = 1 - t.turn
+	t.history = append(t.history, move)
+}
+
+func (t *TicTacToe) Undo() {
+	move := t.history[len(t.history)-1]
+	t.history = t.history[:len(t.history)-1]
+	t.cells[move] = -1
+	t.turn = 1 - t.turn
+}
+
+func (t *TicTacToe) Terminal() bool {
+	return t.winner() >= 0 || len(t.history) == len(t.cells)
+}
+
+// WinValue for a win, minus that for a loss. Otherwise the number of lines
+// still open to the player less those open to the opponent.
+func (t *TicTacToe) Utility(player int) int {
+	switch t.winner() {
+	case player:
+		return WinValue
+	case 1 - player:
+		return -WinValue
+	}
+
+	value := 0
+	for _, line := range lines {
+		mine, theirs := 0, 0
+		for _, cell := range line {
+			switch int(t.cells[cell]) {
+			case player:
+				mine++
+			case 1 - player:
+				theirs++
+			}
+		}
+		if theirs == 0 && mine > 0 {
+			value++
+		}
+		if mine == 0 && theirs > 0 {
+			value--
+		}
+	}
+	return value
+}
+
+func (t *TicTacToe) CurrentPlayer() int {
+	return t.turn
+}
+
+// The cells in base 3
+func (t *TicTacToe) Hash() uint64 {
+	var h uint64
+	for _, cell := range t.cells {
+		h = h*3 + uint64(cell+1)
+	}
+	return h
+}
+
+// Index of the player with three in a row, or -1
+func (t *TicTacToe) winner() int {
+	for _, line := range lines {
+		first := t.cells[line[0]]
+		if first >= 0 && t.cells[line[1]] == first && t.cells[line[2]] == first {
+			return int(first)
+		}
+	}
+	return -1
+}
+
+// The grid in three rows of X, O and '.'
+func (t *TicTacToe) String() string {
+	var sb strings.Builder
+	for i, cell := range t.cells {
+		sb.WriteByte(cellByte(cell))
+		if i%3 == 2 {
+			sb.WriteByte('\n')
+		}
+	}
+	return sb.String()
+}
+
+func cellByte(cell int8) byte {
+	if cell < 0 {
+		return '.'
+	}
+	return game.Tokens[cell]
+}
@@ -0,0 +1,63 @@
+package games
+
+import (
+	"FinalProject/game"
+	"testing"
+)
+
+func play(g game.Game, moves ...int) {
+	for _, move := range moves {
+		g.Apply(move)
+	}
+}
+
+func TestTicTacToeWin(t *testing.T) {
+	ttt := NewTicTacToe()
+	play(ttt, 0, 3, 1, 4)
+	if ttt.Terminal() {
+		t.Fatal("Should not be over yet")
+	}
+
+	ttt.Apply(2)
+	if !ttt.Terminal() || ttt.Utility(0) != WinValue || ttt.Utility(1) != -WinValue || len(ttt.Moves()) != 0 {
+		t.Errorf("Should be won by X:\n%s", ttt)
+	}
+
+	ttt.Undo()
+	if ttt.Terminal() || ttt.CurrentPlayer() != 0 {
+		t.Error("Should undo the win")
+	}
+}
+
+func TestTicTacToeHash(t *testing.T) {
+	a, b := NewTicTacToe(), NewTicTacToe()
+	play(a, 0, 4, 8)
+	play(b, 8, 4, 0)
+	if a.Hash() != b.Hash() {
+		t.Error("Should hash transpositions the same")
+	}
+
+	b.Undo()
+	if a.Hash() == b.Hash() {
+		t.Error("Should hash different positions differently")
+	}
+}
+
+// Perfect play draws
+func TestTicTacToeSolved(t *testing.T) {
+	value, line := game.Search(NewTicTacToe(), 9, 1)
+	if value != 0 || len(line) != 9 {
+		t.Errorf("Should be a draw after 9 moves, got %d after %v", value, line)
+	}
+}
+
+func TestTicTacToeSmartNeverLoses(t *testing.T) {
+	weights := &game.Weights{Decay: 1}
+	smart := &game.SmartPlayer{NumLayers: 9, Weights: weights}
+
+	for i := 0; i < 10; i++ {
+		if winner := game.PlayGame(NewTicTacToe(), [2]game.GamePlayer{&game.RandomPlayer{}, smart}); winner == 0 {
+			t.Fatal("Should never lose to a random player")
+		}
+	}
+}
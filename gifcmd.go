@@ -0,0 +1,37 @@
+package main
+
+import (
+	"FinalProject/game"
+	"flag"
+	"fmt"
+	"os"
+)
+
+// Exports a saved game as an animated GIF
+func gifCommand(args []string) {
+	flags := flag.NewFlagSet("gif", flag.ExitOnError)
+	out := flags.String("out", "game.gif", "file to write")
+	size := flags.Int("size", 40, "cell size in pixels")
+	delay := flags.Int("delay", 80, "time on each move in hundredths of a second")
+	animate := flags.Bool("animate", false, "show tokens falling into place")
+	flags.Usage = func() {
+		fmt.Fprintln(os.Stderr, "usage: gif [flags] game.txt")
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+
+	if flags.NArg() != 1 {
+		flags.Usage()
+		os.Exit(2)
+	}
+	record := loadRecord(flags.Arg(0))
+
+	f, err := os.Create(*out)
+	exitIf(err)
+	if err := game.SaveGIF(f, record, game.GIFOptions{CellSize: *size, Delay: *delay, Animate: *animate}); err != nil {
+		f.Close()
+		exitIf(err)
+	}
+	exitIf(f.Close())
+	fmt.Printf("Wrote %s\n", *out)
+}
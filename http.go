@@ -0,0 +1,31 @@
+package main
+
+import (
+	"FinalProject/api"
+	"FinalProject/web"
+	"flag"
+	"fmt"
+	"net/http"
+)
+
+// Serves the JSON API from the api package and, unless turned off, the
+// browser page from the web package
+func httpCommand(args []string) {
+	flags := flag.NewFlagSet("http", flag.ExitOnError)
+	addr := flags.String("addr", "localhost:8080", "address to listen on")
+	maxDepth := flags.Int("max-depth", api.MaxDepth, "deepest search a request may ask for")
+	ui := flags.Bool("ui", true, "serve the browser page at /")
+	flags.Parse(args)
+
+	server := api.NewServer()
+	server.MaxDepth = *maxDepth
+
+	mux := http.NewServeMux()
+	mux.Handle("/", server)
+	if *ui {
+		web.Register(mux)
+	}
+
+	fmt.Printf("Listening on http://%s\n", *addr)
+	exitIf(http.ListenAndServe(*addr, mux))
+}
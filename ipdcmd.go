@@ -0,0 +1,69 @@
+package main
+
+import (
+	"FinalProject/dilemma"
+	"flag"
+	"fmt"
+	"math/rand"
+	"os"
+	"strings"
+)
+
+// Runs an iterated prisoner's dilemma tournament, then lets the strategies
+// compete as populations
+func ipdCommand(args []string) {
+	flags := flag.NewFlagSet("ipd", flag.ExitOnError)
+	names := flags.String("strategies", "tft,grim,pavlov,random,alld,allc", "comma separated strategies")
+	rounds := flags.Int("rounds", 200, "rounds per match")
+	reps := flags.Int("reps", 10, "matches per pairing")
+	noise := flags.Float64("noise", 0, "chance each move is flipped")
+	seed := flags.Int64("seed", 1, "random seed")
+	generations := flags.Int("generations", 100, "generations of population dynamics")
+	population := flags.Int("population", 0, "evolve a population of this size by the Moran process instead of ecologically")
+	mutation := flags.Float64("mutation", 0.01, "chance an offspring switches strategy in the Moran process")
+	out := flags.String("csv", "", "write the population shares per generation to this file")
+	flags.Parse(args)
+
+	strategies, err := dilemma.Parse(*names)
+	exitIf(err)
+
+	tournament := dilemma.NewTournament(strategies)
+	tournament.Match.Rounds = *rounds
+	tournament.Match.Noise = *noise
+	tournament.Repetitions = *reps
+	tournament.Seed = *seed
+
+	results := tournament.Run()
+	exitIf(dilemma.WriteReport(os.Stdout, results))
+
+	var history [][]float64
+	if *population > 0 {
+		if *population < 2 {
+			exitIf(fmt.Errorf("a population needs at least 2 members"))
+		}
+		moran := &dilemma.Moran{Size: *population, Mutation: *mutation, Rand: rand.New(rand.NewSource(*seed))}
+		history = dilemma.Shares(moran.Evolve(results, *generations), *population)
+		fmt.Printf("\nAfter %d generations of a population of %d:\n", *generations, *population)
+	} else {
+		history = results.Ecological(nil, *generations)
+		fmt.Printf("\nAfter %d ecological generations:\n", *generations)
+	}
+
+	final := history[len(history)-1]
+	var parts []string
+	for i, share := range final {
+		parts = append(parts, fmt.Sprintf("%s %.1f%%", results.Names[i], 100*share))
+	}
+	fmt.Println(strings.Join(parts, ", "))
+
+	if *out != "" {
+		f, err := os.Create(*out)
+		exitIf(err)
+		if err := dilemma.WriteDynamicsCSV(f, results.Names, history); err != nil {
+			f.Close()
+			exitIf(err)
+		}
+		exitIf(f.Close())
+		fmt.Printf("Wrote %s\n", *out)
+	}
+}
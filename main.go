@@ -2,115 +2,186 @@ package main
 
 import (
 	"FinalProject/game"
+	"FinalProject/spectate"
 	"os"
-    "os/exec"
     "fmt"
     "strconv"
+    "strings"
     "runtime"
     "sync"
     "time"
 )
 
+// Commands that can be given as the first argument. Anything else is taken
+// as the number of games to simulate.
+var commands = map[string]func(args []string){
+	"play":  playCommand,
+	"serve": serveCommand,
+	"join":  joinCommand,
+	"http":  httpCommand,
+
+	"engine":     engineCommand,
+	"replay":     replayCommand,
+	"render":     renderCommand,
+	"gif":        gifCommand,
+	"tree":       treeCommand,
+	"book":       bookCommand,
+	"tablebase":  tablebaseCommand,
+	"tune":       tuneCommand,
+	"texel":      texelCommand,
+	"td":         tdCommand,
+	"qlearn":     qlearnCommand,
+	"nn":         nnCommand,
+	"simulate":   simulateCommand,
+	"watch":      watchCommand,
+	"arena":      arenaCommand,
+	"nash":       nashCommand,
+	"ipd":        ipdCommand,
+	"efg":        efgCommand,
+	"cfr":        cfrCommand,
+	"expectimax": expectimaxCommand,
+}
+
 func main() {
 	runtime.GOMAXPROCS(runtime.NumCPU())
 
 	// Get command line input
 	argsWithoutProg := os.Args[1:]
 
+	// Tuned weights replace the built in ones for whatever runs next
+	if len(argsWithoutProg) >= 2 && argsWithoutProg[0] == "-weights" {
+		weights, err := loadWeights(argsWithoutProg[1])
+		exitIf(err)
+		weights.Apply()
+		argsWithoutProg = argsWithoutProg[2:]
+	}
+
+	// Named commands get the rest of the arguments
+	if len(argsWithoutProg) > 0 {
+		if command, ok := commands[argsWithoutProg[0]]; ok {
+			command(argsWithoutProg[1:])
+			return
+		}
+	}
+
 	if len(argsWithoutProg) > 0 {
 		numReps, e := strconv.Atoi(argsWithoutProg[0])
 		if e != nil {
 			numReps = 1
 		}
 
-		wg := &sync.WaitGroup{}
+		var sim = &simulation{numReps: numReps, players: [2]string{"smart:1", "smart:1"}}
+		sim.run()
+	} else {
+		playCommand(nil)
+	}
 
-		var victorySlice = make([]byte, numReps)
-		var wins = [3]int{0, 0, 0}
+	fmt.Println("Simulation complete.")
+}
 
-		start := time.Now()
-		for i := 0; i < numReps; i++ {
-			wg.Add(1)
-			go executeGame(i, &victorySlice, wg)
-		}
+// Settings for a batch of games played at the same time
+type simulation struct {
+	numReps int
 
-		wg.Wait()
+	// Player specs for X and O, as taken by newPlayer
+	players [2]string
 
-		elapsed := time.Since(start)
-	    fmt.Println(elapsed)
+	// Gets every move if anyone is spectating
+	hub *spectate.Hub
 
-		for _, winner := range victorySlice {
+	// Pause after each move so spectators can follow along
+	delay time.Duration
+}
 
-			if winner == ' ' {
-				wins[2]++
-			} else if winner == 'X' {
-				wins[0]++
-			} else if winner == 'O' {
-				wins[1]++
-			}	
+func (sim *simulation) run() {
+	start := time.Now()
+	victorySlice, errs := sim.play()
+
+	elapsed := time.Since(start)
+	fmt.Println(elapsed)
+
+	var wins = [3]int{0, 0, 0}
+	failed := 0
+	for i, winner := range victorySlice {
+		if errs[i] != nil {
+			if failed == 0 {
+				fmt.Fprintln(os.Stderr, errs[i])
+			}
+			failed++
+		} else if winner == ' ' {
+			wins[2]++
+		} else if winner == 'X' {
+			wins[0]++
+		} else if winner == 'O' {
+			wins[1]++
 		}
-
-		fmt.Printf("Player 1 won %d times; Player 2 won %d times; There were %d ties.\n", wins[0], wins[1], wins[2])
-	} else {
-		executeHumanGame()
 	}
 
-	fmt.Println("Simulation complete.")
+	fmt.Printf("Player 1 won %d times; Player 2 won %d times; There were %d ties.\n", wins[0], wins[1], wins[2])
+	if failed > 0 {
+		fmt.Printf("%d games couldn't be played.\n", failed)
+	}
 }
 
-func executeGame(idx int, victorySlice *[]byte, wg *sync.WaitGroup) {
-	defer wg.Done()
-	var b = game.NewBoard()
+// Plays every game and returns each winner, or the error that stopped the
+// game
+func (sim *simulation) play() ([]byte, []error) {
+	wg := &sync.WaitGroup{}
 
-	// Switch off who goes first
-	b.WhoseTurn = idx % 2
+	var victorySlice = make([]byte, sim.numReps)
+	var errs = make([]error, sim.numReps)
 
-	var p1 = game.NewSmartPlayer(0, 1)
-	var p2 = game.NewSmartPlayer(1, 1)
-
-	for !b.CheckEndGame() {
-		if b.WhoseTurn == 0 {
-			p1.MakeMove(b)
-		} else {
-			p2.MakeMove(b)
+	// Every game of an external engine runs its own process, so only
+	// start as many at once as there are CPUs
+	slots := sim.numReps
+	for _, spec := range sim.players {
+		if strings.Contains(spec, "external:") {
+			slots = runtime.NumCPU()
 		}
 	}
+	sem := make(chan struct{}, max(slots, 1))
 
-	(*victorySlice)[idx] = b.Winner
-}
+	for i := 0; i < sim.numReps; i++ {
+		wg.Add(1)
+		go func(idx int) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
 
-func executeHumanGame() {
-	var b = game.NewBoard()
+			victorySlice[idx], errs[idx] = sim.executeGame(idx)
+		}(i)
+	}
 
-	var p1 game.HumanPlayer
-	var p2 = game.NewSmartPlayer(1, 5)
+	wg.Wait()
+	return victorySlice, errs
+}
 
-	for !b.CheckEndGame() {
-		p1.MakeMove(b)
+func (sim *simulation) executeGame(idx int) (byte, error) {
+	p1, err := newPlayer(sim.players[0], 0, nil)
+	if err != nil {
+		return ' ', fmt.Errorf("game %d: %v", idx+1, err)
+	}
+	defer closePlayers(p1)
+	p2, err := newPlayer(sim.players[1], 1, nil)
+	if err != nil {
+		return ' ', fmt.Errorf("game %d: %v", idx+1, err)
+	}
+	defer closePlayers(p2)
+	var match = game.NewMatch(p1, p2)
 
-		// Delay
-		duration := time.Second
-  		time.Sleep(duration)
+	// Switch off who goes first
+	match.Board.WhoseTurn = idx % 2
 
-		// If p1 didn't win, p2 gets to move
-		if (!b.CheckEndGame()) {
-			p2.MakeMove(b)
+	if sim.delay > 0 {
+		match.OnMove = func(match *game.Match, playerIdx int, move int) {
+			time.Sleep(sim.delay)
 		}
-
-		b.Print()
-
-		// Clear terminal
-		cmd := exec.Command("clear") //Linux example, its tested
-        cmd.Stdout = os.Stdout
-        cmd.Run()
-		
 	}
 
-	if b.Winner != ' ' {
-		fmt.Printf("\nPlayer %c is the winner!\n\n", b.Winner)
-	} else {
-		fmt.Println("\nTie game!\n")
+	// Spectators number games from 1
+	if sim.hub != nil {
+		sim.hub.Watch(match, idx + 1)
 	}
 
-	b.Print()
+	return match.Play(), nil
 }
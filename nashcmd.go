@@ -0,0 +1,85 @@
+package main
+
+import (
+	"FinalProject/normalform"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Reports the equilibria and dominance structure of a game given as payoff
+// matrices
+func nashCommand(args []string) {
+	flags := flag.NewFlagSet("nash", flag.ExitOnError)
+	label := flags.Int("label", -1, "run Lemke-Howson from this label instead of picking a solver")
+	flags.Parse(args)
+
+	if flags.NArg() != 1 {
+		exitIf(fmt.Errorf("usage: nash [-label n] game.txt"))
+	}
+	f, err := os.Open(flags.Arg(0))
+	exitIf(err)
+	g, err := normalform.Parse(f)
+	f.Close()
+	exitIf(err)
+
+	exitIf(normalform.Write(os.Stdout, g))
+	fmt.Println()
+
+	players := [2]string{"Row", "Column"}
+	for p, name := range players {
+		if s := g.Dominant(p); s >= 0 {
+			fmt.Printf("%s has a dominant strategy: %s\n", name, g.Names[p][s])
+		}
+	}
+
+	left, steps := g.IESDS()
+	for _, step := range steps {
+		fmt.Printf("%s eliminates %s, dominated by %s\n", players[step.Player], g.Names[step.Player][step.Strategy], g.Names[step.Player][step.By])
+	}
+	if len(steps) > 0 {
+		fmt.Printf("Left after elimination: %s / %s\n", names(g, normalform.Row, left[0]), names(g, normalform.Col, left[1]))
+	}
+
+	pure := g.PureNash()
+	if len(pure) == 0 {
+		fmt.Println("No pure equilibria")
+	}
+	for _, profile := range pure {
+		fmt.Printf("Pure equilibrium: (%s, %s) pays %s, %s\n", g.Names[0][profile.Row], g.Names[1][profile.Col],
+			formatValue(g.Payoffs[0][profile.Row][profile.Col]), formatValue(g.Payoffs[1][profile.Row][profile.Col]))
+	}
+
+	var eq *normalform.Equilibrium
+	if *label >= 0 {
+		eq, err = g.LemkeHowson(*label)
+	} else {
+		eq, err = g.MixedNash()
+	}
+	exitIf(err)
+	fmt.Printf("Mixed equilibrium: row %s, column %s pays %s, %s\n", mix(g, normalform.Row, eq.Row), mix(g, normalform.Col, eq.Col),
+		formatValue(eq.Values[0]), formatValue(eq.Values[1]))
+}
+
+func names(g *normalform.Game, p int, strategies []int) string {
+	var parts []string
+	for _, s := range strategies {
+		parts = append(parts, g.Names[p][s])
+	}
+	return strings.Join(parts, " ")
+}
+
+func mix(g *normalform.Game, p int, probs []float64) string {
+	var parts []string
+	for s, prob := range probs {
+		if prob > 1e-9 {
+			parts = append(parts, fmt.Sprintf("%s %.4g", g.Names[p][s], prob))
+		}
+	}
+	return "(" + strings.Join(parts, ", ") + ")"
+}
+
+func formatValue(v float64) string {
+	return fmt.Sprintf("%.4g", v)
+}
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"FinalProject/game"
+	"FinalProject/netplay"
+	"FinalProject/terminal"
+	"flag"
+	"fmt"
+	"net"
+	"os"
+)
+
+// Hosts a game for one opponent who connects with the join command
+func serveCommand(args []string) {
+	flags := flag.NewFlagSet("serve", flag.ExitOnError)
+	addr := flags.String("addr", ":4000", "address to listen on")
+	as := flags.String("as", "x", "token the host plays (x or o); the opponent gets the other")
+	me := flags.String("me", "human", "host's player: "+playerKinds)
+	first := flags.String("first", "x", "token that moves first (x or o)")
+	flags.Parse(args)
+
+	hostIdx, err := tokenIndex(*as)
+	exitIf(err)
+	firstIdx, err := tokenIndex(*first)
+	exitIf(err)
+
+	renderer := terminal.NewRenderer(os.Stdout)
+	defer renderer.Close()
+
+	local, err := newPlayer(*me, hostIdx, renderer)
+	exitIf(err)
+	defer closePlayers(local)
+
+	listener, err := net.Listen("tcp", *addr)
+	exitIf(err)
+	fmt.Printf("Waiting for an opponent on %s...\n", listener.Addr())
+
+	c, err := listener.Accept()
+	listener.Close()
+	exitIf(err)
+
+	remote, err := netplay.NewRemotePlayer(c, 1-hostIdx)
+	exitIf(err)
+	defer remote.Close()
+
+	var players [2]game.Player
+	if !isHuman(local) {
+		local = &thinkingPlayer{inner: local, renderer: renderer, status: fmt.Sprintf("%c is thinking...", game.Tokens[hostIdx])}
+	}
+	players[hostIdx] = local
+	players[1-hostIdx] = &thinkingPlayer{inner: remote, renderer: renderer, status: fmt.Sprintf("Waiting for %c (%s)...", remote.Token, c.RemoteAddr())}
+
+	match := game.NewMatch(players[0], players[1])
+	match.Board.WhoseTurn = firstIdx
+	match.OnMove = func(match *game.Match, playerIdx int, move int) {
+		renderer.AnimateDrop(match.Board, move, fmt.Sprintf("%c played column %d", game.Tokens[playerIdx], move+1))
+	}
+
+	renderer.Draw(match.Board, -1, "")
+	if _, err := netplay.Host(match, remote); err != nil {
+		renderer.Draw(match.Board, -1, "Opponent disconnected: "+err.Error())
+		return
+	}
+	showResult(renderer, match.Board)
+}
+
+// Joins a game hosted with the serve command
+func joinCommand(args []string) {
+	flags := flag.NewFlagSet("join", flag.ExitOnError)
+	me := flags.String("me", "human", "local player: "+playerKinds)
+	flags.Usage = func() {
+		fmt.Fprintln(os.Stderr, "usage: join [-me player] [host:port]")
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+
+	addr := "localhost:4000"
+	if flags.NArg() > 0 {
+		addr = flags.Arg(0)
+	}
+
+	client, err := netplay.Dial(addr)
+	exitIf(err)
+	defer client.Close()
+
+	renderer := terminal.NewRenderer(os.Stdout)
+	defer renderer.Close()
+
+	playerIdx, _ := tokenIndex(string(client.Token))
+	local, err := newPlayer(*me, playerIdx, renderer)
+	exitIf(err)
+	defer closePlayers(local)
+	if !isHuman(local) {
+		local = &thinkingPlayer{inner: local, renderer: renderer, status: fmt.Sprintf("%c is thinking...", client.Token)}
+	}
+
+	client.OnMove = func(board *game.Board, token byte, move int) {
+		status := fmt.Sprintf("%c played column %d", token, move+1)
+		if token != client.Token && !board.CheckEndGame() {
+			status += "; your move"
+		}
+		renderer.AnimateDrop(board, move, status)
+	}
+	client.OnError = func(message string) {
+		renderer.Draw(client.Board, -1, "Host rejected move: "+message)
+	}
+
+	renderer.Draw(client.Board, -1, fmt.Sprintf("Connected to %s as %c", addr, client.Token))
+	if _, err := client.Play(local); err != nil {
+		renderer.Draw(client.Board, -1, "Connection lost: "+err.Error())
+		return
+	}
+	showResult(renderer, client.Board)
+}
+
+// Returns the index into game.Tokens for "x" or "o"
+func tokenIndex(s string) (int, error) {
+	switch s {
+	case "x", "X":
+		return 0, nil
+	case "o", "O":
+		return 1, nil
+	}
+	return 0, fmt.Errorf("unknown token %q (want x or o)", s)
+}
+
+func exitIf(err error) {
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
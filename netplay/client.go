@@ -0,0 +1,120 @@
+package netplay
+
+import (
+	"FinalProject/game"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+)
+
+// The joining side of a game. The host keeps the real board; the client
+// mirrors it and asks its local player for a move whenever the host says
+// it's our turn.
+type Client struct {
+	Token byte
+	Board *game.Board
+
+	// Called when any move is played, after Board has been updated
+	OnMove func(board *game.Board, token byte, move int)
+
+	// Called when the host rejects one of our moves
+	OnError func(message string)
+
+	conn *conn
+}
+
+// Connects to a host at addr
+func Dial(addr string) (*Client, error) {
+	c, err := net.Dial("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := NewClient(c)
+	if err != nil {
+		c.Close()
+		return nil, err
+	}
+	return client, nil
+}
+
+// Waits for the host's greeting on rw
+func NewClient(rw io.ReadWriteCloser) (*Client, error) {
+	client := &Client{Board: game.NewBoard(), conn: newConn(rw)}
+
+	command, args, err := client.conn.receive()
+	if err != nil {
+		return nil, err
+	}
+	if command != "HELLO" || (args != string(game.Tokens[0]) && args != string(game.Tokens[1])) {
+		return nil, fmt.Errorf("%w: bad greeting %q %q", ErrProtocol, command, args)
+	}
+
+	client.Token = args[0]
+	return client, nil
+}
+
+// Answers the host's requests with moves from player until the game ends.
+// Returns the winning token, or ' ' for a tie.
+func (client *Client) Play(player game.Player) (byte, error) {
+	for {
+		command, args, err := client.conn.receive()
+		if err != nil {
+			return ' ', err
+		}
+
+		switch command {
+		case "BOARD":
+			board, err := game.DecodeBoard(args)
+			if err != nil {
+				return ' ', fmt.Errorf("%w: %v", ErrProtocol, err)
+			}
+			client.Board = board
+
+		case "MOVE":
+			token, colText, _ := strings.Cut(args, " ")
+			col, err := strconv.Atoi(colText)
+			if err != nil || len(token) != 1 || col < 1 || col > game.NumCols {
+				return ' ', fmt.Errorf("%w: bad move %q", ErrProtocol, args)
+			}
+
+			// Our own moves are already on the board
+			if token[0] != client.Token {
+				client.Board.MakeMove(col - 1)
+			}
+			if client.OnMove != nil {
+				client.OnMove(client.Board, token[0], col-1)
+			}
+
+		case "TURN":
+			// If the host rejects the move it sends the board again
+			move := player.MakeMove(client.Board)
+			if err := client.conn.send("MOVE", move+1); err != nil {
+				return ' ', err
+			}
+
+		case "ERROR":
+			if client.OnError != nil {
+				client.OnError(args)
+			}
+
+		case "END":
+			if args == "TIE" {
+				return ' ', nil
+			}
+			if len(args) != 1 {
+				return ' ', fmt.Errorf("%w: bad result %q", ErrProtocol, args)
+			}
+			return args[0], nil
+
+		default:
+			return ' ', fmt.Errorf("%w: unknown command %q", ErrProtocol, command)
+		}
+	}
+}
+
+func (client *Client) Close() error {
+	return client.conn.close()
+}
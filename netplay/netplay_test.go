@@ -0,0 +1,136 @@
+package netplay
+
+import (
+	"FinalProject/game"
+	"bufio"
+	"net"
+	"strings"
+	"testing"
+)
+
+func TestGameOverLocalhost(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+
+	type result struct {
+		winner byte
+		board  *game.Board
+		err    error
+	}
+	joined := make(chan result)
+
+	go func() {
+		client, err := Dial(listener.Addr().String())
+		if err != nil {
+			joined <- result{err: err}
+			return
+		}
+		defer client.Close()
+
+		winner, err := client.Play(&game.RandomPlayer{})
+		joined <- result{winner, client.Board, err}
+	}()
+
+	c, err := listener.Accept()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	remote, err := NewRemotePlayer(c, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer remote.Close()
+
+	match := game.NewMatch(game.NewSmartPlayer(0, 2), remote)
+	winner, err := Host(match, remote)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res := <-joined
+	if res.err != nil {
+		t.Fatal(res.err)
+	}
+
+	if res.winner != winner {
+		t.Errorf("Host saw winner %q but client saw %q", winner, res.winner)
+	}
+
+	if res.board.Encode() != match.Board.Encode() {
+		t.Errorf("Client board doesn't match host:\n%s\n%s", match.Board.Encode(), res.board.Encode())
+	}
+}
+
+func TestRemotePlayerRejectsIllegalMove(t *testing.T) {
+	hostSide, joinSide := net.Pipe()
+	defer joinSide.Close()
+
+	board := game.NewBoard()
+	for i := 0; i < game.NumRows; i++ {
+		board.MakeMove(0)
+	}
+
+	moved := make(chan int)
+	go func() {
+		remote, err := NewRemotePlayer(hostSide, 0)
+		if err != nil {
+			t.Error(err)
+			close(moved)
+			return
+		}
+		moved <- remote.MakeMove(board)
+	}()
+
+	reader := bufio.NewReader(joinSide)
+	expect := func(prefix string) {
+		line, err := reader.ReadString('\n')
+		if err != nil || !strings.HasPrefix(line, prefix) {
+			t.Fatalf("Expected %q, got %q (%v)", prefix, line, err)
+		}
+	}
+
+	expect("HELLO X")
+	expect("BOARD ")
+	expect("TURN")
+
+	// Column 1 is full
+	joinSide.Write([]byte("MOVE 1\n"))
+	expect("ERROR ")
+	expect("BOARD ")
+	expect("TURN")
+
+	joinSide.Write([]byte("MOVE 2\n"))
+	if move := <-moved; move != 1 {
+		t.Errorf("Remote player should have played column 2, not %d", move+1)
+	}
+}
+
+func TestRemotePlayerFallsBackOnDisconnect(t *testing.T) {
+	hostSide, joinSide := net.Pipe()
+
+	go func() {
+		reader := bufio.NewReader(joinSide)
+		reader.ReadString('\n')
+		joinSide.Close()
+	}()
+
+	remote, err := NewRemotePlayer(hostSide, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	board := game.NewBoard()
+	move := remote.MakeMove(board)
+
+	if remote.Err() == nil {
+		t.Error("Err should report the closed connection")
+	}
+
+	if move < 0 || move >= game.NumCols || board.Height(move) != 1 {
+		t.Error("Fallback player should still have made a move")
+	}
+}
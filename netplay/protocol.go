@@ -0,0 +1,71 @@
+// Package netplay plays games between processes over TCP.
+//
+// The host runs the game and the joining side only answers when asked for a
+// move. Every message is one line of text with a command and its arguments
+// separated by spaces. Columns are 1-7 and boards use game.Board.Encode.
+//
+// Host to joiner:
+//
+//	HELLO <token>           the token the joiner plays with
+//	BOARD <board>           the current position, sent before every TURN
+//	MOVE <token> <column>   a move was played by either side
+//	TURN                    the joiner should reply with a move
+//	ERROR <message>         the last reply was rejected
+//	END <token|TIE>         the game is over
+//
+// Joiner to host:
+//
+//	MOVE <column>
+package netplay
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+var ErrProtocol = errors.New("netplay: protocol error")
+
+// Reads and writes protocol lines
+type conn struct {
+	rw     io.ReadWriteCloser
+	reader *bufio.Reader
+}
+
+func newConn(rw io.ReadWriteCloser) *conn {
+	return &conn{rw: rw, reader: bufio.NewReader(rw)}
+}
+
+func (c *conn) send(command string, args ...interface{}) error {
+	line := command
+	for _, arg := range args {
+		line += fmt.Sprintf(" %v", arg)
+	}
+	_, err := io.WriteString(c.rw, line+"\n")
+	return err
+}
+
+// Returns the command and the rest of the next non-empty line
+func (c *conn) receive() (string, string, error) {
+	for {
+		line, err := c.reader.ReadString('\n')
+		if err != nil && (line == "" || err != io.EOF) {
+			return "", "", err
+		}
+
+		line = strings.TrimSpace(line)
+		if line != "" {
+			command, args, _ := strings.Cut(line, " ")
+			return strings.ToUpper(command), args, nil
+		}
+		if err != nil {
+			return "", "", err
+		}
+	}
+}
+
+func (c *conn) close() error {
+	return c.rw.Close()
+}
@@ -0,0 +1,141 @@
+package netplay
+
+import (
+	"FinalProject/game"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// A player on the other end of a connection. The host's match calls
+// MakeMove like for any other player, and the move is asked for over the
+// wire.
+//
+// The Player interface has no way to report a failure, so if the connection
+// breaks the move is made by Fallback instead and Err returns the cause.
+// Callers driving the match should stop once Err is set.
+type RemotePlayer struct {
+	Token    byte
+	Fallback game.Player
+
+	conn *conn
+	err  error
+}
+
+// Greets the joining side on rw and returns a player for it using
+// game.Tokens[playerIdx]
+func NewRemotePlayer(rw io.ReadWriteCloser, playerIdx int) (*RemotePlayer, error) {
+	player := &RemotePlayer{
+		Token:    game.Tokens[playerIdx],
+		Fallback: &game.RandomPlayer{},
+		conn:     newConn(rw),
+	}
+
+	if err := player.conn.send("HELLO", string(player.Token)); err != nil {
+		return nil, err
+	}
+	return player, nil
+}
+
+func (player *RemotePlayer) MakeMove(board *game.Board) int {
+	if player.err == nil {
+		move, err := player.askMove(board)
+		if err == nil {
+			board.MakeMove(move)
+			return move
+		}
+		player.err = err
+	}
+
+	return player.Fallback.MakeMove(board)
+}
+
+// Keeps asking until the other side sends a legal move. The board is sent
+// with every request so a rejected move can't leave the other side's copy
+// out of step.
+func (player *RemotePlayer) askMove(board *game.Board) (int, error) {
+	for {
+		if err := player.conn.send("BOARD", board.Encode()); err != nil {
+			return 0, err
+		}
+		if err := player.conn.send("TURN"); err != nil {
+			return 0, err
+		}
+
+		command, args, err := player.conn.receive()
+		if err != nil {
+			return 0, err
+		}
+		if command != "MOVE" {
+			return 0, fmt.Errorf("%w: expected MOVE, got %q", ErrProtocol, command)
+		}
+
+		col, err := strconv.Atoi(strings.TrimSpace(args))
+		if err == nil && col >= 1 && col <= game.NumCols && board.IsValidMove(col-1) {
+			return col - 1, nil
+		}
+
+		if err := player.conn.send("ERROR", fmt.Sprintf("illegal move %q", args)); err != nil {
+			return 0, err
+		}
+	}
+}
+
+// Tells the other side about a move made by either player
+func (player *RemotePlayer) Notify(board *game.Board, playerIdx int, move int) {
+	if player.err == nil {
+		player.err = player.conn.send("MOVE", string(game.Tokens[playerIdx]), move+1)
+	}
+}
+
+// Sends the final position and result
+func (player *RemotePlayer) End(board *game.Board) {
+	if player.err != nil {
+		return
+	}
+
+	result := "TIE"
+	if board.Winner != ' ' {
+		result = string(board.Winner)
+	}
+
+	if err := player.conn.send("BOARD", board.Encode()); err != nil {
+		player.err = err
+		return
+	}
+	player.err = player.conn.send("END", result)
+}
+
+// Returns the first connection error, if any
+func (player *RemotePlayer) Err() error {
+	return player.err
+}
+
+func (player *RemotePlayer) Close() error {
+	return player.conn.close()
+}
+
+// Plays match to the end where one of the players is remote. Every move is
+// passed on to the remote side and any existing OnMove still runs. Returns
+// early with the connection error if the remote side goes away.
+func Host(match *game.Match, remote *RemotePlayer) (byte, error) {
+	onMove := match.OnMove
+	match.OnMove = func(match *game.Match, playerIdx int, move int) {
+		remote.Notify(match.Board, playerIdx, move)
+		if onMove != nil {
+			onMove(match, playerIdx, move)
+		}
+	}
+	defer func() { match.OnMove = onMove }()
+
+	for remote.Err() == nil && match.Step() {
+	}
+
+	if remote.Err() != nil {
+		return ' ', remote.Err()
+	}
+
+	remote.End(match.Board)
+	return match.Board.Winner, remote.Err()
+}
@@ -0,0 +1,72 @@
+package nn
+
+import (
+	"FinalProject/game"
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+)
+
+// A network file is little endian:
+//
+//	"C4NN", a version byte
+//	uint32 inputs (84), hidden units, columns (7)
+//	float32 weights of the hidden layer, one row of inputs per unit
+//	float32 hidden biases
+//	float32 value head weights, one per hidden unit, then its bias
+//	float32 policy head weights, one row of hidden units per column
+//	float32 policy biases, one per column
+const magic = "C4NN"
+
+const version = 1
+
+func (net *Network) Save(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	bw.WriteString(magic)
+	bw.WriteByte(version)
+	binary.Write(bw, binary.LittleEndian, []uint32{Inputs, uint32(net.Hidden), uint32(len(net.bp))})
+
+	for _, param := range net.params() {
+		for _, weight := range param {
+			binary.Write(bw, binary.LittleEndian, float32(weight))
+		}
+	}
+	return bw.Flush()
+}
+
+func Load(r io.Reader) (*Network, error) {
+	br := bufio.NewReader(r)
+	header := make([]byte, len(magic)+1)
+	if _, err := io.ReadFull(br, header); err != nil {
+		return nil, fmt.Errorf("nn: reading header: %v", err)
+	}
+	if string(header[:len(magic)]) != magic {
+		return nil, errors.New("nn: not a network file")
+	}
+	if header[len(magic)] != version {
+		return nil, fmt.Errorf("nn: unknown version %d", header[len(magic)])
+	}
+
+	sizes := make([]uint32, 3)
+	if err := binary.Read(br, binary.LittleEndian, sizes); err != nil {
+		return nil, fmt.Errorf("nn: reading sizes: %v", err)
+	}
+	if sizes[0] != Inputs || sizes[2] != game.NumCols || sizes[1] == 0 || sizes[1] > 1<<16 {
+		return nil, fmt.Errorf("nn: can't use a %dx%dx%d network", sizes[0], sizes[1], sizes[2])
+	}
+	net := newZeroNetwork(int(sizes[1]))
+
+	buf := make([]byte, 4)
+	for _, param := range net.params() {
+		for i := range param {
+			if _, err := io.ReadFull(br, buf); err != nil {
+				return nil, fmt.Errorf("nn: reading weights: %v", err)
+			}
+			param[i] = float64(math.Float32frombits(binary.LittleEndian.Uint32(buf)))
+		}
+	}
+	return net, nil
+}
@@ -0,0 +1,104 @@
+package nn
+
+import (
+	"FinalProject/game"
+	"math"
+)
+
+const DefaultExploration = 1.5
+
+// AlphaZero style Monte Carlo tree search. Each simulation walks down the
+// tree favouring moves with high value and high prior but few visits,
+// scores the new leaf with the network and passes the value back up.
+type MCTS struct {
+	Net         *Network
+	Simulations int
+
+	// Weight of the prior against the values found so far
+	Exploration float64
+}
+
+type node struct {
+	board    *game.Board
+	prior    float64
+	visits   int
+	value    float64 // total, for the player who moved into this node
+	children []*node
+	moves    []int
+	terminal bool
+	result   float64 // for the side to move, when terminal
+}
+
+// Returns how many simulations went through each column
+func (search *MCTS) Visits(board *game.Board) [game.NumCols]int {
+	// The first simulation only expands the root
+	root := &node{board: board.DuplicateBoard()}
+	for i := 0; i < max(search.Simulations, 2); i++ {
+		search.simulate(root)
+	}
+
+	var visits [game.NumCols]int
+	for i, child := range root.children {
+		visits[root.moves[i]] = child.visits
+	}
+	return visits
+}
+
+// Runs one simulation from n and returns the value for the side to move
+// at n
+func (search *MCTS) simulate(n *node) float64 {
+	if n.terminal {
+		return n.result
+	}
+
+	if n.children == nil {
+		if result, over := outcome(n.board); over {
+			n.terminal, n.result = true, result
+			return result
+		}
+
+		value, priors := search.Net.Predict(n.board)
+		for c := 0; c < game.NumCols; c++ {
+			if n.board.IsValidMove(c) {
+				child := n.board.DuplicateBoard()
+				child.MakeMove(c)
+				n.children = append(n.children, &node{board: child, prior: priors[c]})
+				n.moves = append(n.moves, c)
+			}
+		}
+		return value
+	}
+
+	// PUCT selection
+	total := 0
+	for _, child := range n.children {
+		total += child.visits
+	}
+	best, bestScore := n.children[0], math.Inf(-1)
+	for _, child := range n.children {
+		q := 0.0
+		if child.visits > 0 {
+			q = child.value / float64(child.visits)
+		}
+		score := q + search.Exploration*child.prior*math.Sqrt(float64(total)+1)/float64(1+child.visits)
+		if score > bestScore {
+			best, bestScore = child, score
+		}
+	}
+
+	// The child's value is for the other side
+	value := -search.simulate(best)
+	best.visits++
+	best.value += value
+	return value
+}
+
+func mostVisited(visits [game.NumCols]int) int {
+	best := -1
+	for c, n := range visits {
+		if n > 0 && (best < 0 || n > visits[best]) {
+			best = c
+		}
+	}
+	return best
+}
@@ -0,0 +1,185 @@
+// Package nn is a small neural network for Connect Four in plain Go. It
+// maps a board to a value and move priors, learns from self-play or
+// labeled positions, and plays either as SmartPlayer's leaf evaluation or
+// by guiding a Monte Carlo tree search.
+//
+// The network sees the board from the side to move: 42 inputs for its
+// stones and 42 for the opponent's. One hidden layer of ReLU units feeds a
+// value head, tanh of a weighted sum giving the expected result for the
+// side to move from -1 to 1, and a policy head, a softmax over the legal
+// columns.
+package nn
+
+import (
+	"FinalProject/game"
+	"math"
+	"math/rand"
+)
+
+const Inputs = 2 * game.NumCols * game.NumRows
+
+// Leaf values from Evaluate are the value head times this
+const Scale = 1000
+
+type Network struct {
+	Hidden int
+
+	w1 []float64 // Hidden rows of Inputs
+	b1 []float64 // Hidden
+	wv []float64 // Hidden
+	bv []float64 // 1
+	wp []float64 // NumCols rows of Hidden
+	bp []float64 // NumCols
+}
+
+// Returns a network with small random weights
+func NewNetwork(hidden int, rng *rand.Rand) *Network {
+	net := newZeroNetwork(hidden)
+
+	// He initialization for the ReLU layer, Xavier for the heads
+	for i := range net.w1 {
+		net.w1[i] = rng.NormFloat64() * math.Sqrt(2/float64(Inputs))
+	}
+	for i := range net.wv {
+		net.wv[i] = rng.NormFloat64() * math.Sqrt(1/float64(hidden))
+	}
+	for i := range net.wp {
+		net.wp[i] = rng.NormFloat64() * math.Sqrt(1/float64(hidden))
+	}
+	return net
+}
+
+func newZeroNetwork(hidden int) *Network {
+	return &Network{
+		Hidden: hidden,
+		w1:     make([]float64, hidden*Inputs),
+		b1:     make([]float64, hidden),
+		wv:     make([]float64, hidden),
+		bv:     make([]float64, 1),
+		wp:     make([]float64, game.NumCols*hidden),
+		bp:     make([]float64, game.NumCols),
+	}
+}
+
+// Every weight array, in the order they're saved
+func (net *Network) params() [][]float64 {
+	return [][]float64{net.w1, net.b1, net.wv, net.bv, net.wp, net.bp}
+}
+
+// The board from the side to move
+func encode(board *game.Board) []float64 {
+	x := make([]float64, Inputs)
+	me := game.Tokens[board.WhoseTurn]
+	for c := 0; c < game.NumCols; c++ {
+		for r := 0; r < game.NumRows; r++ {
+			switch token := board.At(c, r); {
+			case token == ' ':
+			case token == me:
+				x[c*game.NumRows+r] = 1
+			default:
+				x[Inputs/2+c*game.NumRows+r] = 1
+			}
+		}
+	}
+	return x
+}
+
+// Values kept from a forward pass for backpropagation
+type activations struct {
+	x     []float64
+	pre   []float64
+	h     []float64
+	value float64
+	prior [game.NumCols]float64
+	legal [game.NumCols]bool
+}
+
+func (net *Network) forward(board *game.Board) *activations {
+	act := &activations{x: encode(board), pre: make([]float64, net.Hidden), h: make([]float64, net.Hidden)}
+
+	for j := 0; j < net.Hidden; j++ {
+		sum := net.b1[j]
+		row := net.w1[j*Inputs : (j+1)*Inputs]
+		for i, x := range act.x {
+			if x != 0 {
+				sum += row[i] * x
+			}
+		}
+		act.pre[j] = sum
+		act.h[j] = math.Max(0, sum)
+	}
+
+	v := net.bv[0]
+	for j, h := range act.h {
+		v += net.wv[j] * h
+	}
+	act.value = math.Tanh(v)
+
+	// Softmax over legal columns only
+	var logits [game.NumCols]float64
+	top := math.Inf(-1)
+	for c := 0; c < game.NumCols; c++ {
+		act.legal[c] = board.IsValidMove(c)
+		if !act.legal[c] {
+			continue
+		}
+		logits[c] = net.bp[c]
+		for j, h := range act.h {
+			logits[c] += net.wp[c*net.Hidden+j] * h
+		}
+		top = math.Max(top, logits[c])
+	}
+
+	total := 0.0
+	for c := 0; c < game.NumCols; c++ {
+		if act.legal[c] {
+			act.prior[c] = math.Exp(logits[c] - top)
+			total += act.prior[c]
+		}
+	}
+	for c := range act.prior {
+		if total > 0 {
+			act.prior[c] /= total
+		}
+	}
+	return act
+}
+
+// Returns the expected result for the side to move, from -1 to 1, and the
+// chance the network gives each column of being the best move. Illegal
+// columns get 0.
+func (net *Network) Predict(board *game.Board) (float64, [game.NumCols]float64) {
+	act := net.forward(board)
+	return act.value, act.prior
+}
+
+// Scores the board for SmartPlayer's search. Finished games are scored
+// like a four on the board.
+func (net *Network) Evaluate(board *game.Board, token byte) int {
+	var value int
+	if result, over := outcome(board); over {
+		value = int(result) * game.ConfigValues["TTTT"]
+	} else {
+		v, _ := net.Predict(board)
+		value = int(v * Scale)
+	}
+
+	if token != game.Tokens[board.WhoseTurn] {
+		return -value
+	}
+	return value
+}
+
+// Returns the result for the side to move, -1 if the other side has just
+// won or 0 for a full board, and whether the game is over
+func outcome(board *game.Board) (float64, bool) {
+	if board.WinningLine() != nil {
+		return -1, true
+	}
+	for c := 0; c < game.NumCols; c++ {
+		if board.IsValidMove(c) {
+			return 0, false
+		}
+	}
+	return 0, true
+}
@@ -0,0 +1,163 @@
+package nn
+
+import (
+	"FinalProject/game"
+	"FinalProject/tune"
+	"bytes"
+	"encoding/binary"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func testBoard() *game.Board {
+	board := game.NewBoard()
+	board.PlayMoves([]int{3, 3, 2, 4, 0, 0, 0, 0, 0, 0})
+	return board
+}
+
+func TestPredict(t *testing.T) {
+	net := NewNetwork(16, rand.New(rand.NewSource(1)))
+	value, priors := net.Predict(testBoard())
+
+	if value <= -1 || value >= 1 {
+		t.Errorf("Value %v should be inside (-1, 1)", value)
+	}
+
+	total := 0.0
+	for _, p := range priors {
+		total += p
+	}
+	if math.Abs(total-1) > 1e-9 || priors[0] != 0 {
+		t.Errorf("Priors should sum to 1 with nothing on the full column: %v", priors)
+	}
+}
+
+// Compares backpropagation with finite differences
+func TestGradient(t *testing.T) {
+	net := NewNetwork(8, rand.New(rand.NewSource(1)))
+	example := Example{Board: testBoard(), Value: 0.5, Policy: []float64{0, 0.1, 0.2, 0.3, 0.2, 0.1, 0.1}}
+
+	grad := newZeroNetwork(net.Hidden)
+	net.backward(example, grad)
+
+	const eps = 1e-6
+	params, grads := net.params(), grad.params()
+	for p := range params {
+		for _, i := range []int{0, len(params[p]) / 2, len(params[p]) - 1} {
+			old := params[p][i]
+			params[p][i] = old + eps
+			up := net.Loss([]Example{example})
+			params[p][i] = old - eps
+			down := net.Loss([]Example{example})
+			params[p][i] = old
+
+			numeric := (up - down) / (2 * eps)
+			if math.Abs(numeric-grads[p][i]) > 1e-4*math.Max(1, math.Abs(numeric)) {
+				t.Errorf("Param %d[%d]: backprop %v, numeric %v", p, i, grads[p][i], numeric)
+			}
+		}
+	}
+}
+
+func TestTrainingLowersLoss(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	net := NewNetwork(16, rng)
+
+	var examples []Example
+	for i := 0; i < 20; i++ {
+		board := game.NewBoard()
+		for j := 0; j < 6; j++ {
+			(&game.RandomPlayer{}).MakeMove(board)
+		}
+		examples = append(examples, Example{Board: board, Value: float64(i%3 - 1)})
+	}
+
+	before := net.Loss(examples)
+	trainer := NewTrainer(net, rng)
+	for epoch := 0; epoch < 50; epoch++ {
+		trainer.Epoch(examples)
+	}
+	if after := net.Loss(examples); after >= before {
+		t.Errorf("Loss should drop: %v before, %v after", before, after)
+	}
+}
+
+func TestExamplesFromSamples(t *testing.T) {
+	board := game.NewBoard()
+	board.MakeMove(3)
+
+	examples := ExamplesFromSamples([]tune.Sample{{Board: board, Result: 1}, {Board: game.NewBoard(), Result: 0.5}})
+	if examples[0].Value != -1 || examples[1].Value != 0 {
+		t.Errorf("X won, so O to move should have lost: %+v", examples)
+	}
+}
+
+func TestSaveLoad(t *testing.T) {
+	net := NewNetwork(4, rand.New(rand.NewSource(1)))
+
+	var buf bytes.Buffer
+	if err := net.Save(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if want := 5 + 12 + 4*(4*Inputs+4+4+1+7*4+7); buf.Len() != want {
+		t.Errorf("File should be %d bytes, got %d", want, buf.Len())
+	}
+
+	loaded, err := Load(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	a, _ := net.Predict(testBoard())
+	b, _ := loaded.Predict(testBoard())
+	if math.Abs(a-b) > 1e-5 {
+		t.Errorf("Loaded network predicts %v, not %v", b, a)
+	}
+}
+
+func TestLoadRejectsBadSizes(t *testing.T) {
+	for _, sizes := range [][]uint32{{Inputs, 0xFFFFFFFF, game.NumCols}, {Inputs, 4, 9}, {3, 4, game.NumCols}} {
+		var buf bytes.Buffer
+		buf.WriteString(magic)
+		buf.WriteByte(version)
+		binary.Write(&buf, binary.LittleEndian, sizes)
+
+		if _, err := Load(&buf); err == nil {
+			t.Errorf("Should reject a network with sizes %v", sizes)
+		}
+	}
+}
+
+func TestPlayersTakeWin(t *testing.T) {
+	net := NewNetwork(8, rand.New(rand.NewSource(1)))
+	players := map[string]game.Player{
+		"leaf": NewLeafPlayer(0, 2, net),
+		"mcts": NewMCTSPlayer(200, net),
+	}
+
+	for name, player := range players {
+		board := game.NewBoard()
+		board.PlayMoves([]int{0, 1, 0, 1, 0, 1})
+		if move := player.MakeMove(board); move != 0 {
+			t.Errorf("%s player should take the win, played %d", name, move)
+		}
+	}
+}
+
+func TestSelfPlay(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	examples := SelfPlay(NewNetwork(8, rng), 1, 10, rng)
+	if len(examples) < 7 {
+		t.Fatalf("A game has at least 7 moves, got %d examples", len(examples))
+	}
+
+	last := examples[len(examples)-1]
+	if last.Value != 1 && last.Value != 0 {
+		t.Errorf("The last mover can't have lost, got %v", last.Value)
+	}
+	for i := 1; i < len(examples); i++ {
+		if examples[i].Value != -examples[i-1].Value {
+			t.Fatal("Values should alternate between the sides")
+		}
+	}
+}
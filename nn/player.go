@@ -0,0 +1,44 @@
+package nn
+
+import "FinalProject/game"
+
+// Plays with a network, either by searching Depth layers with the network
+// as SmartPlayer's leaf evaluation, or, if Simulations is set, by MCTS
+// guided by its priors
+type NNPlayer struct {
+	Net *Network
+
+	Depth       int
+	Simulations int
+
+	search *game.SmartPlayer
+}
+
+func NewLeafPlayer(playerIdx int, depth int, net *Network) *NNPlayer {
+	search := game.NewSmartPlayer(playerIdx, depth)
+	search.Evaluator = net
+	return &NNPlayer{Net: net, Depth: depth, search: search}
+}
+
+func NewMCTSPlayer(simulations int, net *Network) *NNPlayer {
+	return &NNPlayer{Net: net, Simulations: simulations}
+}
+
+func (player *NNPlayer) MakeMove(board *game.Board) int {
+	if player.Simulations == 0 {
+		return player.search.MakeMove(board)
+	}
+
+	search := &MCTS{Net: player.Net, Simulations: player.Simulations, Exploration: DefaultExploration}
+	move := mostVisited(search.Visits(board))
+	board.MakeMove(move)
+	return move
+}
+
+// Value from the last leaf search. MCTS only counts visits, so it has none.
+func (player *NNPlayer) LastEvaluation() (int, bool) {
+	if player.Simulations != 0 {
+		return 0, false
+	}
+	return player.search.LastEvaluation()
+}
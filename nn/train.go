@@ -0,0 +1,202 @@
+package nn
+
+import (
+	"FinalProject/game"
+	"FinalProject/tune"
+	"math"
+	"math/rand"
+)
+
+// A position to learn from
+type Example struct {
+	Board *game.Board
+
+	// Result for the side to move, from -1 to 1
+	Value float64
+
+	// Share of the search each column got, or nil to learn only the value
+	Policy []float64
+}
+
+// Turns positions labeled with results, such as solver output, into value
+// examples
+func ExamplesFromSamples(samples []tune.Sample) []Example {
+	examples := make([]Example, 0, len(samples))
+	for _, sample := range samples {
+		// Results are 1 for an X win; values are for the side to move
+		value := 2*sample.Result - 1
+		if sample.Board.WhoseTurn == 1 {
+			value = -value
+		}
+		examples = append(examples, Example{Board: sample.Board, Value: value})
+	}
+	return examples
+}
+
+// Stochastic gradient descent on squared value error plus policy cross
+// entropy
+type Trainer struct {
+	Net *Network
+
+	LearningRate float64
+	BatchSize    int
+
+	// L2 penalty on the weights
+	WeightDecay float64
+
+	Rand *rand.Rand
+}
+
+func NewTrainer(net *Network, rng *rand.Rand) *Trainer {
+	return &Trainer{Net: net, LearningRate: 0.01, BatchSize: 32, WeightDecay: 1e-4, Rand: rng}
+}
+
+// Makes one pass over the examples in random order. Returns the mean loss.
+func (trainer *Trainer) Epoch(examples []Example) float64 {
+	order := trainer.Rand.Perm(len(examples))
+	total := 0.0
+
+	for start := 0; start < len(order); start += trainer.BatchSize {
+		end := min(start+trainer.BatchSize, len(order))
+		grad := newZeroNetwork(trainer.Net.Hidden)
+		for _, i := range order[start:end] {
+			total += trainer.Net.backward(examples[i], grad)
+		}
+
+		scale := trainer.LearningRate / float64(end-start)
+		params, grads := trainer.Net.params(), grad.params()
+		for p := range params {
+			for i := range params[p] {
+				params[p][i] -= scale*grads[p][i] + trainer.LearningRate*trainer.WeightDecay*params[p][i]
+			}
+		}
+	}
+	return total / float64(len(examples))
+}
+
+// Returns the loss on the examples without learning from them
+func (net *Network) Loss(examples []Example) float64 {
+	total := 0.0
+	for _, example := range examples {
+		total += net.loss(example, net.forward(example.Board))
+	}
+	return total / float64(len(examples))
+}
+
+func (net *Network) loss(example Example, act *activations) float64 {
+	loss := (act.value - example.Value) * (act.value - example.Value)
+	for c, target := range example.Policy {
+		if target > 0 && act.legal[c] {
+			loss -= target * math.Log(math.Max(act.prior[c], 1e-12))
+		}
+	}
+	return loss
+}
+
+// Adds the gradient of the example's loss to grad and returns the loss
+func (net *Network) backward(example Example, grad *Network) float64 {
+	act := net.forward(example.Board)
+
+	// Value head: d/dv (v - z)^2 through tanh
+	dv := 2 * (act.value - example.Value) * (1 - act.value*act.value)
+	grad.bv[0] += dv
+	dh := make([]float64, net.Hidden)
+	for j, h := range act.h {
+		grad.wv[j] += dv * h
+		dh[j] += dv * net.wv[j]
+	}
+
+	// Policy head: softmax with cross entropy gives prior - target
+	if example.Policy != nil {
+		for c := 0; c < game.NumCols; c++ {
+			if !act.legal[c] {
+				continue
+			}
+			dl := act.prior[c] - example.Policy[c]
+			grad.bp[c] += dl
+			for j, h := range act.h {
+				grad.wp[c*net.Hidden+j] += dl * h
+				dh[j] += dl * net.wp[c*net.Hidden+j]
+			}
+		}
+	}
+
+	// Hidden layer
+	for j := range dh {
+		if act.pre[j] <= 0 {
+			continue
+		}
+		grad.b1[j] += dh[j]
+		row := grad.w1[j*Inputs : (j+1)*Inputs]
+		for i, x := range act.x {
+			if x != 0 {
+				row[i] += dh[j] * x
+			}
+		}
+	}
+	return net.loss(example, act)
+}
+
+// Plays games against itself with MCTS and returns every position with the
+// search's move shares and the final result. The first few moves are
+// sampled from the search so games differ.
+func SelfPlay(net *Network, games int, simulations int, rng *rand.Rand) []Example {
+	var examples []Example
+	for g := 0; g < games; g++ {
+		board := game.NewBoard()
+		var positions []Example
+
+		for ply := 0; !board.CheckEndGame(); ply++ {
+			search := &MCTS{Net: net, Simulations: simulations, Exploration: DefaultExploration}
+			visits := search.Visits(board)
+
+			total := 0
+			for _, n := range visits {
+				total += n
+			}
+			policy := make([]float64, game.NumCols)
+			for c, n := range visits {
+				policy[c] = float64(n) / float64(total)
+			}
+			positions = append(positions, Example{Board: board.DuplicateBoard(), Policy: policy})
+
+			var move int
+			if ply < 8 {
+				move = sample(policy, rng)
+			} else {
+				move = mostVisited(visits)
+			}
+			board.MakeMove(move)
+		}
+
+		// Each position gets the result for whoever was to move in it
+		for _, example := range positions {
+			switch board.Winner {
+			case ' ':
+				example.Value = 0
+			case game.Tokens[example.Board.WhoseTurn]:
+				example.Value = 1
+			default:
+				example.Value = -1
+			}
+			examples = append(examples, example)
+		}
+	}
+	return examples
+}
+
+func sample(weights []float64, rng *rand.Rand) int {
+	pick := rng.Float64()
+	last := 0
+	for c, w := range weights {
+		if w <= 0 {
+			continue
+		}
+		last = c
+		if pick < w {
+			return c
+		}
+		pick -= w
+	}
+	return last
+}
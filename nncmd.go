@@ -0,0 +1,105 @@
+package main
+
+import (
+	"FinalProject/game"
+	"FinalProject/nn"
+	"flag"
+	"fmt"
+	"math/rand"
+	"os"
+	"time"
+)
+
+// Trains a network by rounds of self-play, optionally mixed with labeled
+// positions, e.g.
+//
+//	nn -iterations 20 -games 25 -out net.nn
+//	nn -iterations 5 -games 0 -out net.nn solved.txt
+func nnCommand(args []string) {
+	flags := flag.NewFlagSet("nn", flag.ExitOnError)
+	hidden := flags.Int("hidden", 64, "hidden units in a new network")
+	start := flags.String("start", "", "network file to keep training (default a new network)")
+	iterations := flags.Int("iterations", 10, "rounds of self-play and training")
+	games := flags.Int("games", 20, "self-play games per round")
+	sims := flags.Int("sims", 100, "MCTS simulations per self-play move")
+	epochs := flags.Int("epochs", 5, "passes over each round's positions")
+	rate := flags.Float64("rate", 0.01, "learning rate")
+	skip := flags.Int("skip", 4, "leave out this many opening moves of saved games")
+	out := flags.String("out", "net.nn", "file to write after each round")
+	compare := flags.Int("compare", 10, "games against a depth 3 smart player after training (0 to skip)")
+	flags.Usage = func() {
+		fmt.Fprintln(os.Stderr, "usage: nn [flags] [labeled positions or saved games...]")
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+
+	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
+	net := nn.NewNetwork(*hidden, rng)
+	if *start != "" {
+		var err error
+		net, err = loadNN(*start)
+		exitIf(err)
+	}
+
+	var labeled []nn.Example
+	for _, path := range flags.Args() {
+		samples, err := loadSamples(path, *skip)
+		exitIf(err)
+		labeled = append(labeled, nn.ExamplesFromSamples(samples)...)
+	}
+
+	trainer := nn.NewTrainer(net, rng)
+	trainer.LearningRate = *rate
+
+	for i := 1; i <= *iterations; i++ {
+		began := time.Now()
+		examples := append(nn.SelfPlay(net, *games, *sims, rng), labeled...)
+		if len(examples) == 0 {
+			exitIf(fmt.Errorf("nothing to train on; play some games or give labeled positions"))
+		}
+
+		loss := 0.0
+		for epoch := 0; epoch < *epochs; epoch++ {
+			loss = trainer.Epoch(examples)
+		}
+		fmt.Printf("Round %d: %d positions, loss %.4f (%v)\n", i, len(examples), loss, time.Since(began).Round(time.Millisecond))
+
+		f, err := os.Create(*out)
+		exitIf(err)
+		if err := net.Save(f); err != nil {
+			f.Close()
+			exitIf(err)
+		}
+		exitIf(f.Close())
+	}
+	fmt.Printf("Wrote %s\n", *out)
+
+	if *compare > 0 {
+		var wins [3]int
+		for i := 0; i < *compare; i++ {
+			nnIdx := i % 2
+			var players [2]game.Player
+			players[nnIdx] = nn.NewMCTSPlayer(*sims, net)
+			players[1-nnIdx] = game.NewSmartPlayer(1-nnIdx, 3)
+
+			switch game.NewMatch(players[0], players[1]).Play() {
+			case game.Tokens[nnIdx]:
+				wins[0]++
+			case ' ':
+				wins[2]++
+			default:
+				wins[1]++
+			}
+		}
+		fmt.Printf("MCTS with the network won %d, the smart player won %d, %d ties\n", wins[0], wins[1], wins[2])
+	}
+}
+
+func loadNN(path string) (*nn.Network, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return nn.Load(f)
+}
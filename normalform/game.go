@@ -0,0 +1,232 @@
+// Package normalform solves two player games given as payoff matrices.
+//
+// A game file lists the payoffs one row per line, a cell per column. A cell
+// is the row player's payoff and the column player's payoff separated by a
+// comma, or one number for a zero-sum game, where the column player gets
+// minus it. Optional rows and cols lines name the strategies.
+//
+//	# prisoner's dilemma
+//	rows Cooperate Defect
+//	cols Cooperate Defect
+//	3,3 0,5
+//	5,0 1,1
+//
+// Blank lines and lines starting with # are ignored.
+package normalform
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// The row player is 0 and the column player 1
+const (
+	Row = 0
+	Col = 1
+)
+
+type Game struct {
+	// Strategy names for each player
+	Names [2][]string
+
+	// Payoffs[p][i][j] is player p's payoff when the row player plays i and
+	// the column player j
+	Payoffs [2][][]float64
+}
+
+// Builds a game from each player's payoff matrix, naming strategies by
+// number
+func New(rowPayoffs, colPayoffs [][]float64) (*Game, error) {
+	if len(rowPayoffs) == 0 || len(rowPayoffs[0]) == 0 {
+		return nil, fmt.Errorf("normalform: game has no strategies")
+	}
+	if len(colPayoffs) != len(rowPayoffs) {
+		return nil, fmt.Errorf("normalform: payoff matrices differ in size")
+	}
+	for i := range rowPayoffs {
+		if len(rowPayoffs[i]) != len(rowPayoffs[0]) || len(colPayoffs[i]) != len(rowPayoffs[0]) {
+			return nil, fmt.Errorf("normalform: payoff matrices differ in size")
+		}
+	}
+
+	g := &Game{Payoffs: [2][][]float64{rowPayoffs, colPayoffs}}
+	for p := range g.Names {
+		for i := 0; i < g.Strategies(p); i++ {
+			g.Names[p] = append(g.Names[p], strconv.Itoa(i+1))
+		}
+	}
+	return g, nil
+}
+
+// Builds a zero-sum game from the row player's payoffs
+func NewZeroSum(payoffs [][]float64) (*Game, error) {
+	negated := make([][]float64, len(payoffs))
+	for i, row := range payoffs {
+		negated[i] = make([]float64, len(row))
+		for j, v := range row {
+			negated[i][j] = -v
+		}
+	}
+	return New(payoffs, negated)
+}
+
+// Number of strategies player p has
+func (g *Game) Strategies(p int) int {
+	if p == Row {
+		return len(g.Payoffs[0])
+	}
+	return len(g.Payoffs[0][0])
+}
+
+// Whether the payoffs sum to zero in every cell
+func (g *Game) ZeroSum() bool {
+	for i, row := range g.Payoffs[Row] {
+		for j, v := range row {
+			if v+g.Payoffs[Col][i][j] != 0 {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// payoff gives player p's payoff when p plays s and the opponent t
+func (g *Game) payoff(p, s, t int) float64 {
+	if p == Row {
+		return g.Payoffs[Row][s][t]
+	}
+	return g.Payoffs[Col][t][s]
+}
+
+// Expected payoffs when the players mix with probabilities x and y
+func (g *Game) Expected(x, y []float64) (float64, float64) {
+	var values [2]float64
+	for i := range x {
+		for j := range y {
+			for p := range values {
+				values[p] += x[i] * y[j] * g.Payoffs[p][i][j]
+			}
+		}
+	}
+	return values[Row], values[Col]
+}
+
+// Whether neither player can gain more than eps by switching to a pure
+// strategy
+func (g *Game) IsNash(x, y []float64, eps float64) bool {
+	rowValue, colValue := g.Expected(x, y)
+	for i := 0; i < g.Strategies(Row); i++ {
+		if v, _ := g.Expected(pure(len(x), i), y); v > rowValue+eps {
+			return false
+		}
+	}
+	for j := 0; j < g.Strategies(Col); j++ {
+		if _, v := g.Expected(x, pure(len(y), j)); v > colValue+eps {
+			return false
+		}
+	}
+	return true
+}
+
+func pure(n, s int) []float64 {
+	mix := make([]float64, n)
+	mix[s] = 1
+	return mix
+}
+
+func Parse(r io.Reader) (*Game, error) {
+	var names [2][]string
+	var payoffs [2][][]float64
+	zeroSum, seen := false, false
+
+	scanner := bufio.NewScanner(r)
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		switch fields[0] {
+		case "rows":
+			names[Row] = fields[1:]
+			continue
+		case "cols":
+			names[Col] = fields[1:]
+			continue
+		}
+
+		var rowPayoffs, colPayoffs []float64
+		for _, cell := range fields {
+			rowText, colText, pair := strings.Cut(cell, ",")
+			if seen && pair == zeroSum {
+				return nil, fmt.Errorf("normalform: line %d: mixes zero-sum and two payoff cells", lineNum)
+			}
+			zeroSum, seen = !pair, true
+
+			rowValue, err := strconv.ParseFloat(rowText, 64)
+			if err != nil {
+				return nil, fmt.Errorf("normalform: line %d: bad payoff %q", lineNum, cell)
+			}
+			colValue := -rowValue
+			if pair {
+				if colValue, err = strconv.ParseFloat(colText, 64); err != nil {
+					return nil, fmt.Errorf("normalform: line %d: bad payoff %q", lineNum, cell)
+				}
+			}
+			rowPayoffs = append(rowPayoffs, rowValue)
+			colPayoffs = append(colPayoffs, colValue)
+		}
+
+		if len(payoffs[Row]) > 0 && len(rowPayoffs) != len(payoffs[Row][0]) {
+			return nil, fmt.Errorf("normalform: line %d: has %d cells, not %d", lineNum, len(rowPayoffs), len(payoffs[Row][0]))
+		}
+		payoffs[Row] = append(payoffs[Row], rowPayoffs)
+		payoffs[Col] = append(payoffs[Col], colPayoffs)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	g, err := New(payoffs[Row], payoffs[Col])
+	if err != nil {
+		return nil, err
+	}
+	for p := range names {
+		if names[p] == nil {
+			continue
+		}
+		if len(names[p]) != g.Strategies(p) {
+			return nil, fmt.Errorf("normalform: %d names for %d strategies", len(names[p]), g.Strategies(p))
+		}
+		g.Names[p] = names[p]
+	}
+	return g, nil
+}
+
+// Writes the game in the format Parse reads
+func Write(w io.Writer, g *Game) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "rows %s\n", strings.Join(g.Names[Row], " "))
+	fmt.Fprintf(bw, "cols %s\n", strings.Join(g.Names[Col], " "))
+
+	zeroSum := g.ZeroSum()
+	for i, row := range g.Payoffs[Row] {
+		cells := make([]string, len(row))
+		for j, v := range row {
+			cells[j] = formatPayoff(v)
+			if !zeroSum {
+				cells[j] += "," + formatPayoff(g.Payoffs[Col][i][j])
+			}
+		}
+		fmt.Fprintln(bw, strings.Join(cells, " "))
+	}
+	return bw.Flush()
+}
+
+func formatPayoff(v float64) string {
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
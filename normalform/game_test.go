@@ -0,0 +1,64 @@
+package normalform
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+const prisonersDilemma = `# prisoner's dilemma
+rows Cooperate Defect
+cols Cooperate Defect
+3,3 0,5
+5,0 1,1
+`
+
+func mustParse(t *testing.T, text string) *Game {
+	t.Helper()
+	g, err := Parse(strings.NewReader(text))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return g
+}
+
+func TestParse(t *testing.T) {
+	g := mustParse(t, prisonersDilemma)
+	if g.Strategies(Row) != 2 || g.Strategies(Col) != 2 || g.Names[Col][1] != "Defect" {
+		t.Fatalf("Should read a 2x2 game with names: %+v", g)
+	}
+	if g.Payoffs[Row][1][0] != 5 || g.Payoffs[Col][1][0] != 0 || g.ZeroSum() {
+		t.Errorf("Should read both payoffs: %v", g.Payoffs)
+	}
+
+	pennies := mustParse(t, "1 -1\n-1 1\n")
+	if !pennies.ZeroSum() || pennies.Payoffs[Col][0][1] != 1 || pennies.Names[Row][0] != "1" {
+		t.Errorf("Should read a zero-sum game: %+v", pennies)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, text := range []string{"", "1,2 3\n", "1 2\n3\n", "1 x\n", "rows A\n1 2\n3 4\n"} {
+		if _, err := Parse(strings.NewReader(text)); err == nil {
+			t.Errorf("Should reject %q", text)
+		}
+	}
+}
+
+func TestWriteRoundTrip(t *testing.T) {
+	for _, text := range []string{prisonersDilemma, "1 -1\n-1 1\n"} {
+		g := mustParse(t, text)
+
+		var buf bytes.Buffer
+		if err := Write(&buf, g); err != nil {
+			t.Fatal(err)
+		}
+		again := mustParse(t, buf.String())
+
+		var buf2 bytes.Buffer
+		Write(&buf2, again)
+		if buf.String() != buf2.String() {
+			t.Errorf("Should read back what it writes:\n%s\n%s", buf.String(), buf2.String())
+		}
+	}
+}
@@ -0,0 +1,146 @@
+package normalform
+
+import (
+	"fmt"
+	"math"
+)
+
+// Finds a Nash equilibrium of any two player game by the Lemke-Howson
+// algorithm, starting by dropping label, 0 to m+n-1 for the m row and n
+// column strategies. Different labels can find different equilibria.
+// Degenerate games may not be solved.
+func (g *Game) LemkeHowson(label int) (*Equilibrium, error) {
+	m, n := g.Strategies(Row), g.Strategies(Col)
+	if label < 0 || label >= m+n {
+		return nil, fmt.Errorf("normalform: label %d out of range 0-%d", label, m+n-1)
+	}
+
+	// Positive payoffs keep the polytopes bounded
+	lowest := math.Inf(1)
+	for p := range g.Payoffs {
+		for _, row := range g.Payoffs[p] {
+			for _, v := range row {
+				lowest = math.Min(lowest, v)
+			}
+		}
+	}
+	shift := 1 - lowest
+
+	// Columns are labels: 0 to m-1 for the row strategies, m to m+n-1 for
+	// the column strategies, then the right hand side. The row player's
+	// tableau is B^T x + s = 1 over x (labels 0 to m-1) and slacks s; the
+	// column player's is A y + r = 1 over slacks r and y (labels m up).
+	rowTableau := make([][]float64, n)
+	rowBasis := make([]int, n)
+	for j := range rowTableau {
+		rowTableau[j] = make([]float64, m+n+1)
+		for i := 0; i < m; i++ {
+			rowTableau[j][i] = g.Payoffs[Col][i][j] + shift
+		}
+		rowTableau[j][m+j] = 1
+		rowTableau[j][m+n] = 1
+		rowBasis[j] = m + j
+	}
+
+	colTableau := make([][]float64, m)
+	colBasis := make([]int, m)
+	for i := range colTableau {
+		colTableau[i] = make([]float64, m+n+1)
+		colTableau[i][i] = 1
+		for j := 0; j < n; j++ {
+			colTableau[i][m+j] = g.Payoffs[Row][i][j] + shift
+		}
+		colTableau[i][m+n] = 1
+		colBasis[i] = i
+	}
+
+	tableaux := [2][][]float64{rowTableau, colTableau}
+	bases := [2][]int{rowBasis, colBasis}
+
+	// Row strategy labels enter the row player's tableau
+	t := Row
+	if label >= m {
+		t = Col
+	}
+
+	entering := label
+	for steps := 0; ; steps++ {
+		if steps > 10*(m+n)*(m+n) {
+			return nil, fmt.Errorf("normalform: Lemke-Howson didn't finish; the game may be degenerate")
+		}
+
+		leave := ratioTest(tableaux[t], entering)
+		if leave < 0 {
+			return nil, fmt.Errorf("normalform: Lemke-Howson found no pivot; the game may be degenerate")
+		}
+		leaving := bases[t][leave]
+		pivot(tableaux[t], leave, entering)
+		bases[t][leave] = entering
+
+		// Every label is present again
+		if leaving == label {
+			break
+		}
+		entering = leaving
+		t = 1 - t
+	}
+
+	x := strategyFrom(rowTableau, rowBasis, 0, m)
+	y := strategyFrom(colTableau, colBasis, m, n)
+	rowValue, colValue := g.Expected(x, y)
+	return &Equilibrium{Row: x, Col: y, Values: [2]float64{rowValue, colValue}}, nil
+}
+
+// Row of the minimum ratio test for the entering column, or -1
+func ratioTest(tableau [][]float64, col int) int {
+	last := len(tableau[0]) - 1
+	leave := -1
+	best := math.Inf(1)
+	for i, row := range tableau {
+		if row[col] <= eps {
+			continue
+		}
+		if ratio := row[last] / row[col]; ratio < best-eps {
+			best = ratio
+			leave = i
+		}
+	}
+	return leave
+}
+
+// Reads the n variables labelled from first onwards off the tableau and
+// normalizes them into probabilities
+func strategyFrom(tableau [][]float64, basis []int, first, n int) []float64 {
+	mix := make([]float64, n)
+	total := 0.0
+	for i, label := range basis {
+		if label >= first && label < first+n {
+			mix[label-first] = tableau[i][len(tableau[i])-1]
+			total += mix[label-first]
+		}
+	}
+	for s := range mix {
+		mix[s] /= total
+	}
+	return mix
+}
+
+// An equilibrium by linear programming for zero-sum games, otherwise by
+// Lemke-Howson from the first label that works
+func (g *Game) MixedNash() (*Equilibrium, error) {
+	if g.ZeroSum() {
+		return g.SolveZeroSum()
+	}
+
+	var err error
+	for label := 0; label < g.Strategies(Row)+g.Strategies(Col); label++ {
+		var eq *Equilibrium
+		if eq, err = g.LemkeHowson(label); err == nil && g.IsNash(eq.Row, eq.Col, 1e-6) {
+			return eq, nil
+		}
+	}
+	if err == nil {
+		err = fmt.Errorf("normalform: Lemke-Howson found no equilibrium")
+	}
+	return nil, err
+}
@@ -0,0 +1,52 @@
+package normalform
+
+import "testing"
+
+func TestLemkeHowsonBattle(t *testing.T) {
+	battle := mustParse(t, "2,1 0,0\n0,0 1,2\n")
+
+	found := make(map[[2]float64]bool)
+	for label := 0; label < 4; label++ {
+		eq, err := battle.LemkeHowson(label)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !battle.IsNash(eq.Row, eq.Col, 1e-9) {
+			t.Errorf("Label %d should give an equilibrium, got %+v", label, eq)
+		}
+		found[[2]float64{eq.Row[0], eq.Col[0]}] = true
+	}
+
+	if !found[[2]float64{1, 1}] || !found[[2]float64{0, 0}] {
+		t.Errorf("Should find both pure equilibria, got %v", found)
+	}
+}
+
+func TestLemkeHowsonMixed(t *testing.T) {
+	// Only a mixed equilibrium: both players mix evenly
+	g := mustParse(t, "3,1 0,2\n1,2 2,1\n")
+	eq, err := g.LemkeHowson(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !nearAll(eq.Row, []float64{0.5, 0.5}) || !nearAll(eq.Col, []float64{0.5, 0.5}) {
+		t.Errorf("Should find the mixed equilibrium, got %+v", eq)
+	}
+}
+
+func TestMixedNash(t *testing.T) {
+	for _, text := range []string{prisonersDilemma, "1 -1\n-1 1\n", "3,1 0,2\n1,2 2,1\n"} {
+		g := mustParse(t, text)
+		eq, err := g.MixedNash()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !g.IsNash(eq.Row, eq.Col, 1e-6) {
+			t.Errorf("Should be an equilibrium of\n%s got %+v", text, eq)
+		}
+	}
+
+	if _, err := mustParse(t, prisonersDilemma).LemkeHowson(4); err == nil {
+		t.Error("Should reject a label out of range")
+	}
+}
@@ -0,0 +1,148 @@
+package normalform
+
+import (
+	"fmt"
+	"math"
+)
+
+const eps = 1e-9
+
+// Maximizes c·x subject to a x <= b and x >= 0, where b >= 0 so the slack
+// variables make a feasible start. Returns x, the dual prices of the
+// constraints and the optimal value. Pivots by Bland's rule, so it can't
+// cycle.
+func simplex(a [][]float64, b, c []float64) ([]float64, []float64, float64, error) {
+	m, n := len(a), len(c)
+
+	// Each row is the constraint's coefficients, then the slacks, then b.
+	// The last row is the objective.
+	tableau := make([][]float64, m+1)
+	basis := make([]int, m)
+	for i := range a {
+		if b[i] < 0 {
+			return nil, nil, 0, fmt.Errorf("normalform: simplex needs b >= 0")
+		}
+		tableau[i] = make([]float64, n+m+1)
+		copy(tableau[i], a[i])
+		tableau[i][n+i] = 1
+		tableau[i][n+m] = b[i]
+		basis[i] = n + i
+	}
+	tableau[m] = make([]float64, n+m+1)
+	for j := range c {
+		tableau[m][j] = -c[j]
+	}
+
+	for {
+		enter := -1
+		for j := 0; j < n+m; j++ {
+			if tableau[m][j] < -eps {
+				enter = j
+				break
+			}
+		}
+		if enter < 0 {
+			break
+		}
+
+		leave := -1
+		best := math.Inf(1)
+		for i := 0; i < m; i++ {
+			if tableau[i][enter] <= eps {
+				continue
+			}
+			ratio := tableau[i][n+m] / tableau[i][enter]
+			if ratio < best-eps || (ratio < best+eps && leave >= 0 && basis[i] < basis[leave]) {
+				best = ratio
+				leave = i
+			}
+		}
+		if leave < 0 {
+			return nil, nil, 0, fmt.Errorf("normalform: linear program is unbounded")
+		}
+
+		pivot(tableau, leave, enter)
+		basis[leave] = enter
+	}
+
+	x := make([]float64, n)
+	for i, v := range basis {
+		if v < n {
+			x[v] = tableau[i][n+m]
+		}
+	}
+	return x, tableau[m][n : n+m], tableau[m][n+m], nil
+}
+
+// Makes column col a unit vector with its one in row row
+func pivot(tableau [][]float64, row, col int) {
+	p := tableau[row][col]
+	for j := range tableau[row] {
+		tableau[row][j] /= p
+	}
+	for i := range tableau {
+		if i == row || tableau[i][col] == 0 {
+			continue
+		}
+		f := tableau[i][col]
+		for j := range tableau[i] {
+			tableau[i][j] -= f * tableau[row][j]
+		}
+	}
+}
+
+// A mixed strategy for each player and their expected payoffs
+type Equilibrium struct {
+	Row, Col []float64
+	Values   [2]float64
+}
+
+// Solves a zero-sum game by linear programming. The row player's value is
+// Values[Row].
+func (g *Game) SolveZeroSum() (*Equilibrium, error) {
+	if !g.ZeroSum() {
+		return nil, fmt.Errorf("normalform: game is not zero-sum")
+	}
+
+	// Make every payoff positive so the value is too
+	lowest := math.Inf(1)
+	for _, row := range g.Payoffs[Row] {
+		for _, v := range row {
+			lowest = math.Min(lowest, v)
+		}
+	}
+	shift := 1 - lowest
+
+	// The column player holds the row player to v: A y <= v. With y' = y/v
+	// that is A y' <= 1, maximizing sum y' = 1/v.
+	m, n := g.Strategies(Row), g.Strategies(Col)
+	a := make([][]float64, m)
+	b := make([]float64, m)
+	c := make([]float64, n)
+	for i := range a {
+		a[i] = make([]float64, n)
+		for j := range a[i] {
+			a[i][j] = g.Payoffs[Row][i][j] + shift
+		}
+		b[i] = 1
+	}
+	for j := range c {
+		c[j] = 1
+	}
+
+	y, prices, total, err := simplex(a, b, c)
+	if err != nil {
+		return nil, err
+	}
+
+	// The dual prices are the row player's strategy scaled the same way
+	value := 1 / total
+	x := make([]float64, m)
+	for i := range x {
+		x[i] = prices[i] * value
+	}
+	for j := range y {
+		y[j] *= value
+	}
+	return &Equilibrium{Row: x, Col: y, Values: [2]float64{value - shift, shift - value}}, nil
+}
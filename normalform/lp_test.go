@@ -0,0 +1,60 @@
+package normalform
+
+import (
+	"math"
+	"testing"
+)
+
+func near(a, b float64) bool {
+	return math.Abs(a-b) < 1e-6
+}
+
+func nearAll(got, want []float64) bool {
+	for i := range want {
+		if !near(got[i], want[i]) {
+			return false
+		}
+	}
+	return len(got) == len(want)
+}
+
+func TestSimplex(t *testing.T) {
+	// max 3x + 5y, x <= 4, 2y <= 12, 3x + 2y <= 18: x = 2, y = 6
+	x, prices, value, err := simplex([][]float64{{1, 0}, {0, 2}, {3, 2}}, []float64{4, 12, 18}, []float64{3, 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !nearAll(x, []float64{2, 6}) || !near(value, 36) || !nearAll(prices, []float64{0, 1.5, 1}) {
+		t.Errorf("Should solve the textbook LP, got %v %v %v", x, prices, value)
+	}
+
+	if _, _, _, err := simplex([][]float64{{1, -1}}, []float64{1}, []float64{1, 1}); err == nil {
+		t.Error("Should report an unbounded program")
+	}
+}
+
+func TestSolveZeroSum(t *testing.T) {
+	rps := mustParse(t, "0 -1 1\n1 0 -1\n-1 1 0\n")
+	eq, err := rps.SolveZeroSum()
+	if err != nil {
+		t.Fatal(err)
+	}
+	third := []float64{1.0 / 3, 1.0 / 3, 1.0 / 3}
+	if !nearAll(eq.Row, third) || !nearAll(eq.Col, third) || !near(eq.Values[Row], 0) {
+		t.Errorf("Rock paper scissors should be uniform with value 0, got %+v", eq)
+	}
+
+	// Row mixes (3/4, 1/4) for a value of 5/2
+	g := mustParse(t, "3 2\n1 4\n")
+	eq, err = g.SolveZeroSum()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !nearAll(eq.Row, []float64{0.75, 0.25}) || !nearAll(eq.Col, []float64{0.5, 0.5}) || !near(eq.Values[Row], 2.5) {
+		t.Errorf("Should solve the 2x2 game, got %+v", eq)
+	}
+
+	if _, err := mustParse(t, prisonersDilemma).SolveZeroSum(); err == nil {
+		t.Error("Should refuse a game that isn't zero-sum")
+	}
+}
@@ -0,0 +1,113 @@
+package normalform
+
+// A pure strategy for each player
+type Profile struct {
+	Row, Col int
+}
+
+// Every profile where each strategy is a best response to the other
+func (g *Game) PureNash() []Profile {
+	var equilibria []Profile
+	for i := 0; i < g.Strategies(Row); i++ {
+		for j := 0; j < g.Strategies(Col); j++ {
+			if g.bestResponse(Row, i, j) && g.bestResponse(Col, j, i) {
+				equilibria = append(equilibria, Profile{Row: i, Col: j})
+			}
+		}
+	}
+	return equilibria
+}
+
+// Whether s is among player p's best responses to t
+func (g *Game) bestResponse(p, s, t int) bool {
+	for other := 0; other < g.Strategies(p); other++ {
+		if g.payoff(p, other, t) > g.payoff(p, s, t) {
+			return false
+		}
+	}
+	return true
+}
+
+// Player p's strictly dominant strategy, or -1 if there isn't one
+func (g *Game) Dominant(p int) int {
+	all := g.all(1 - p)
+	for s := 0; s < g.Strategies(p); s++ {
+		dominant := true
+		for other := 0; other < g.Strategies(p) && dominant; other++ {
+			dominant = other == s || g.dominates(p, s, other, all)
+		}
+		if dominant {
+			return s
+		}
+	}
+	return -1
+}
+
+// Whether s is strictly better than other for player p against each of the
+// opponent's strategies
+func (g *Game) dominates(p, s, other int, opponent []int) bool {
+	for _, t := range opponent {
+		if g.payoff(p, s, t) <= g.payoff(p, other, t) {
+			return false
+		}
+	}
+	return true
+}
+
+func (g *Game) all(p int) []int {
+	strategies := make([]int, g.Strategies(p))
+	for s := range strategies {
+		strategies[s] = s
+	}
+	return strategies
+}
+
+// A strategy removed by IESDS and the one that dominated it
+type Elimination struct {
+	Player, Strategy, By int
+}
+
+// Iterated elimination of strictly dominated strategies. Returns the
+// strategies that survive for each player and the eliminations in order.
+// Only domination by pure strategies is considered.
+func (g *Game) IESDS() ([2][]int, []Elimination) {
+	left := [2][]int{g.all(Row), g.all(Col)}
+	var steps []Elimination
+
+	for changed := true; changed; {
+		changed = false
+		for p := range left {
+			for i := 0; i < len(left[p]); i++ {
+				s := left[p][i]
+				for _, other := range left[p] {
+					if other != s && g.dominates(p, other, s, left[1-p]) {
+						steps = append(steps, Elimination{Player: p, Strategy: s, By: other})
+						left[p] = append(left[p][:i:i], left[p][i+1:]...)
+						i--
+						changed = true
+						break
+					}
+				}
+			}
+		}
+	}
+	return left, steps
+}
+
+// The game with only the given strategies
+func (g *Game) Restrict(strategies [2][]int) *Game {
+	sub := &Game{}
+	for p := range sub.Names {
+		for _, s := range strategies[p] {
+			sub.Names[p] = append(sub.Names[p], g.Names[p][s])
+		}
+		for _, i := range strategies[Row] {
+			row := make([]float64, 0, len(strategies[Col]))
+			for _, j := range strategies[Col] {
+				row = append(row, g.Payoffs[p][i][j])
+			}
+			sub.Payoffs[p] = append(sub.Payoffs[p], row)
+		}
+	}
+	return sub
+}
@@ -0,0 +1,59 @@
+package normalform
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPureNash(t *testing.T) {
+	pd := mustParse(t, prisonersDilemma)
+	if got := pd.PureNash(); !reflect.DeepEqual(got, []Profile{{1, 1}}) {
+		t.Errorf("Should find mutual defection, got %v", got)
+	}
+
+	battle := mustParse(t, "2,1 0,0\n0,0 1,2\n")
+	if got := battle.PureNash(); !reflect.DeepEqual(got, []Profile{{0, 0}, {1, 1}}) {
+		t.Errorf("Should find both coordinated outcomes, got %v", got)
+	}
+
+	pennies := mustParse(t, "1 -1\n-1 1\n")
+	if got := pennies.PureNash(); len(got) != 0 {
+		t.Errorf("Matching pennies has no pure equilibrium, got %v", got)
+	}
+}
+
+func TestDominant(t *testing.T) {
+	pd := mustParse(t, prisonersDilemma)
+	if pd.Dominant(Row) != 1 || pd.Dominant(Col) != 1 {
+		t.Error("Defecting should be dominant")
+	}
+
+	battle := mustParse(t, "2,1 0,0\n0,0 1,2\n")
+	if battle.Dominant(Row) != -1 || battle.Dominant(Col) != -1 {
+		t.Error("Battle of the sexes has no dominant strategy")
+	}
+}
+
+// The example from Gibbons, A Primer in Game Theory, 1.1.B
+func TestIESDS(t *testing.T) {
+	g := mustParse(t, `
+rows Up Down
+cols Left Middle Right
+1,0 1,2 0,1
+0,3 0,1 2,0
+`)
+
+	left, steps := g.IESDS()
+	if !reflect.DeepEqual(left, [2][]int{{0}, {1}}) {
+		t.Errorf("Should leave (Up, Middle), got %v", left)
+	}
+	want := []Elimination{{Col, 2, 1}, {Row, 1, 0}, {Col, 0, 1}}
+	if !reflect.DeepEqual(steps, want) {
+		t.Errorf("Should eliminate Right, Down then Left, got %v", steps)
+	}
+
+	sub := g.Restrict(left)
+	if sub.Names[Col][0] != "Middle" || sub.Payoffs[Col][0][0] != 2 {
+		t.Errorf("Should restrict to what's left: %+v", sub)
+	}
+}
@@ -0,0 +1,155 @@
+package main
+
+import (
+	"FinalProject/game"
+	"FinalProject/terminal"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+)
+
+// Plays one game on the terminal between any two kinds of player, e.g.
+//
+//	play -x human -o human        hot-seat
+//	play -x smart:3 -o smart:5    watch two engines
+//	play -x smart -o human        human moves second
+//	play -load game.txt           carry on with a saved game
+func playCommand(args []string) {
+	flags := flag.NewFlagSet("play", flag.ExitOnError)
+	xSpec := flags.String("x", "human", "player using X: "+playerKinds)
+	oSpec := flags.String("o", "smart:5", "player using O: "+playerKinds)
+	first := flags.String("first", "x", "token that moves first (x or o)")
+	delay := flags.Duration("delay", 500*time.Millisecond, "pause after each computer move")
+	load := flags.String("load", "", "resume the game saved in this file; its players are used unless -x or -o is given")
+	save := flags.String("save", "", "save the game to this file after every move (default the -load file)")
+	flags.Parse(args)
+
+	var record *game.Record
+	if *load != "" {
+		record = loadRecord(*load)
+		if *save == "" {
+			*save = *load
+		}
+
+		set := make(map[string]bool)
+		flags.Visit(func(f *flag.Flag) { set[f.Name] = true })
+		if !set["x"] && record.Players[0] != "" {
+			*xSpec = record.Players[0]
+		}
+		if !set["o"] && record.Players[1] != "" {
+			*oSpec = record.Players[1]
+		}
+	} else {
+		firstIdx, err := tokenIndex(*first)
+		exitIf(err)
+		record = game.NewRecord(*xSpec, *oSpec, firstIdx)
+	}
+
+	renderer := terminal.NewRenderer(os.Stdout)
+	defer renderer.Close()
+
+	var players [2]game.Player
+	for i, spec := range []string{*xSpec, *oSpec} {
+		player, err := newPlayer(spec, i, renderer)
+		exitIf(err)
+		players[i] = player
+		defer closePlayers(player)
+	}
+
+	match := game.NewMatch(players[0], players[1])
+	board, err := record.Board()
+	exitIf(err)
+	match.Board = board
+	match.Moves = record.Columns()
+
+	// Show each computer as thinking until it moves
+	for i, player := range players {
+		if !isHuman(player) {
+			players[i] = &thinkingPlayer{inner: player, renderer: renderer, status: fmt.Sprintf("%c is thinking...", game.Tokens[i])}
+		}
+	}
+	match.Players = players
+
+	match.OnMove = func(match *game.Match, playerIdx int, move int) {
+		record.AddMove(move, lastEval(players[playerIdx]), "")
+		record.Finish(match.Board)
+		if *save != "" {
+			exitIf(saveRecord(*save, record))
+		}
+
+		renderer.AnimateDrop(match.Board, move, fmt.Sprintf("%c played column %d", game.Tokens[playerIdx], move+1))
+		if !isHuman(players[playerIdx]) && !match.Board.CheckEndGame() {
+			time.Sleep(*delay)
+		}
+	}
+
+	renderer.Draw(match.Board, -1, "")
+	match.Play()
+	showResult(renderer, match.Board)
+}
+
+func showResult(renderer *terminal.Renderer, board *game.Board) {
+	if board.Winner != ' ' {
+		renderer.Draw(board, -1, fmt.Sprintf("Player %c is the winner!", board.Winner))
+	} else {
+		renderer.Draw(board, -1, "Tie game!")
+	}
+}
+
+func loadRecord(path string) *game.Record {
+	f, err := os.Open(path)
+	exitIf(err)
+	defer f.Close()
+
+	record, err := game.LoadGame(f)
+	exitIf(err)
+	return record
+}
+
+func saveRecord(path string, record *game.Record) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	if err := game.SaveGame(f, record); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// Returns the engine's evaluation behind the player's last move, if it has
+// one
+func lastEval(player game.Player) *int {
+	if thinking, ok := player.(*thinkingPlayer); ok {
+		player = thinking.inner
+	}
+	if evaluating, ok := player.(game.Evaluating); ok {
+		if value, ok := evaluating.LastEvaluation(); ok {
+			return &value
+		}
+	}
+	return nil
+}
+
+// Wraps a player that doesn't draw anything itself so the board shows a
+// status while it picks a move
+type thinkingPlayer struct {
+	inner    game.Player
+	renderer *terminal.Renderer
+	status   string
+}
+
+func (player *thinkingPlayer) MakeMove(board *game.Board) int {
+	if player.renderer.ANSI() {
+		player.renderer.Draw(board, -1, player.status)
+	}
+	return player.inner.MakeMove(board)
+}
+
+func isHuman(player game.Player) bool {
+	_, ok := player.(*terminal.HumanPlayer)
+	return ok
+}
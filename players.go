@@ -0,0 +1,213 @@
+package main
+
+import (
+	"FinalProject/book"
+	"FinalProject/engine"
+	"FinalProject/game"
+	"FinalProject/nn"
+	"FinalProject/td"
+	"FinalProject/terminal"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const playerKinds = "human, random, smart[:depth[:tablebase]], external:<engine command>, book:<file>[:<player>], td:<file>[:depth], nn:<file>[:mcts:sims|:leaf:depth] or expectimax[:depth[:uniform|softmax|<records>]]"
+
+// Builds a player from a command line spec such as "human" or "smart:5".
+// playerIdx is the index into game.Tokens the player will use. renderer may
+// be nil when nobody is at the terminal.
+func newPlayer(spec string, playerIdx int, renderer *terminal.Renderer) (game.Player, error) {
+	kind, arg, _ := strings.Cut(spec, ":")
+
+	switch kind {
+	case "human":
+		if renderer == nil {
+			return nil, fmt.Errorf("human players need a terminal")
+		}
+		player := terminal.NewHumanPlayer(renderer, os.Stdin)
+		player.Prompt = fmt.Sprintf("Player %c: ←/→ to choose, enter to drop, q to quit", game.Tokens[playerIdx])
+		return player, nil
+	case "random":
+		return &game.RandomPlayer{}, nil
+	case "smart":
+		arg, tbPath, _ := strings.Cut(arg, ":")
+		depth := 5
+		if arg != "" {
+			var err error
+			if depth, err = strconv.Atoi(arg); err != nil || depth < 1 {
+				return nil, fmt.Errorf("bad search depth in %q", spec)
+			}
+		}
+
+		player := game.NewSmartPlayer(playerIdx, depth)
+		if tbPath != "" {
+			tb, err := loadShared(loadTablebase, tbPath)
+			if err != nil {
+				return nil, err
+			}
+			player.Endgame = tb
+		}
+		return player, nil
+	case "external":
+		command := strings.Fields(arg)
+		if len(command) == 0 {
+			return nil, fmt.Errorf("external player needs a command, e.g. external:./solver")
+		}
+		return engine.NewExternalPlayer(command[0], command[1:]...)
+	case "td":
+		path, depthArg, _ := strings.Cut(arg, ":")
+		if path == "" {
+			return nil, fmt.Errorf("td player needs a network file, e.g. td:td.weights:4")
+		}
+		depth := 4
+		if depthArg != "" {
+			var err error
+			if depth, err = strconv.Atoi(depthArg); err != nil || depth < 1 {
+				return nil, fmt.Errorf("bad search depth in %q", spec)
+			}
+		}
+
+		net, err := loadShared(loadNetwork, path)
+		if err != nil {
+			return nil, err
+		}
+		return td.NewTDPlayer(playerIdx, depth, net), nil
+	case "nn":
+		path, rest, _ := strings.Cut(arg, ":")
+		if path == "" {
+			return nil, fmt.Errorf("nn player needs a network file, e.g. nn:net.nn:mcts:200")
+		}
+		mode, countArg, _ := strings.Cut(rest, ":")
+		count := 200
+		if mode == "leaf" {
+			count = 4
+		}
+		if countArg != "" {
+			var err error
+			if count, err = strconv.Atoi(countArg); err != nil || count < 1 {
+				return nil, fmt.Errorf("bad count in %q", spec)
+			}
+		}
+
+		net, err := loadShared(loadNN, path)
+		if err != nil {
+			return nil, err
+		}
+		switch mode {
+		case "", "mcts":
+			return nn.NewMCTSPlayer(count, net), nil
+		case "leaf":
+			return nn.NewLeafPlayer(playerIdx, count, net), nil
+		}
+		return nil, fmt.Errorf("nn player mode should be mcts or leaf, not %q", mode)
+	case "expectimax":
+		depthArg, modelArg, _ := strings.Cut(arg, ":")
+		depth := 4
+		if depthArg != "" {
+			var err error
+			if depth, err = strconv.Atoi(depthArg); err != nil || depth < 1 {
+				return nil, fmt.Errorf("bad search depth in %q", spec)
+			}
+		}
+
+		model, err := newOpponentModel(modelArg)
+		if err != nil {
+			return nil, err
+		}
+		return game.NewExpectimaxPlayer(depth, model), nil
+	case "book":
+		// The rest of the spec is the player to use out of book
+		path, innerSpec, _ := strings.Cut(arg, ":")
+		if path == "" {
+			return nil, fmt.Errorf("book player needs a file, e.g. book:opening.book:smart:5")
+		}
+		if innerSpec == "" {
+			innerSpec = "smart"
+		}
+
+		b, err := loadShared(loadBook, path)
+		if err != nil {
+			return nil, err
+		}
+		inner, err := newPlayer(innerSpec, playerIdx, renderer)
+		if err != nil {
+			return nil, err
+		}
+		return book.NewBookPlayer(b, inner), nil
+	}
+
+	return nil, fmt.Errorf("unknown player %q (want %s)", spec, playerKinds)
+}
+
+// Builds an opponent model from uniform, softmax, or comma separated game
+// records to learn from
+func newOpponentModel(spec string) (game.OpponentModel, error) {
+	switch spec {
+	case "", "uniform":
+		return game.UniformModel{}, nil
+	case "softmax":
+		return game.SoftmaxModel{}, nil
+	}
+
+	// Each player learns from its own games on top of the records
+	model, err := loadShared(loadLearnedModel, spec)
+	if err != nil {
+		return nil, err
+	}
+	return model.Clone(), nil
+}
+
+// Learns from comma separated game records
+func loadLearnedModel(paths string) (*game.LearnedModel, error) {
+	model := game.NewLearnedModel(game.SoftmaxModel{})
+	for _, path := range strings.Split(paths, ",") {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		record, err := game.LoadGame(f)
+		f.Close()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", path, err)
+		}
+		model.LearnRecord(record)
+	}
+	return model, nil
+}
+
+// Files players have loaded, by path. Games only read networks, books,
+// tablebases and learned models (players learn on clones), so a batch of
+// games shares one copy of each instead of reading the file for every game.
+var shared = struct {
+	sync.Mutex
+	files map[string]any
+}{files: make(map[string]any)}
+
+// Loads path with load the first time it's asked for and returns the same
+// value after that
+func loadShared[T any](load func(path string) (T, error), path string) (T, error) {
+	shared.Lock()
+	defer shared.Unlock()
+
+	if v, ok := shared.files[path].(T); ok {
+		return v, nil
+	}
+	v, err := load(path)
+	if err == nil {
+		shared.files[path] = v
+	}
+	return v, err
+}
+
+// Shuts down players that hold processes or connections
+func closePlayers(players ...game.Player) {
+	for _, player := range players {
+		if closer, ok := player.(io.Closer); ok {
+			closer.Close()
+		}
+	}
+}
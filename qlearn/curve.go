@@ -0,0 +1,65 @@
+package qlearn
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+)
+
+// One measurement of a learner during training
+type Point struct {
+	Episode int
+	States  int
+
+	// Shares of games won and drawn against each opponent
+	RandomWin  float64
+	RandomDraw float64
+	SearchWin  float64
+	SearchDraw float64
+}
+
+// Trains for episodes, measuring against both opponents every evalEvery
+// episodes (and before the first) with evalGames games each. report, if not
+// nil, gets each point as it's measured.
+func Train(player *QLearningPlayer, episodes int, evalEvery int, evalGames int, random Policy, search Policy, report func(Point)) []Point {
+	var curve []Point
+	measure := func(episode int) {
+		point := Point{Episode: episode, States: player.States()}
+		point.RandomWin, point.RandomDraw = player.Evaluate(random, evalGames)
+		point.SearchWin, point.SearchDraw = player.Evaluate(search, evalGames)
+		curve = append(curve, point)
+		if report != nil {
+			report(point)
+		}
+	}
+
+	measure(0)
+	for episode := 1; episode <= episodes; episode++ {
+		player.TrainEpisode()
+		if evalEvery > 0 && episode%evalEvery == 0 {
+			measure(episode)
+		}
+	}
+	return curve
+}
+
+// Writes the curve as CSV with a header row. The search columns are for the
+// search opponent from Opponents.
+func WriteCSV(w io.Writer, curve []Point) error {
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"episode", "states", "random_win", "random_draw", "search_win", "search_draw"})
+
+	format := func(f float64) string { return strconv.FormatFloat(f, 'f', 4, 64) }
+	for _, point := range curve {
+		cw.Write([]string{
+			strconv.Itoa(point.Episode),
+			strconv.Itoa(point.States),
+			format(point.RandomWin),
+			format(point.RandomDraw),
+			format(point.SearchWin),
+			format(point.SearchDraw),
+		})
+	}
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,153 @@
+package qlearn
+
+import (
+	"FinalProject/game"
+	"FinalProject/tablebase"
+	"math/rand"
+	"time"
+)
+
+// Learns the value of each move in each position it meets by playing
+// against itself. Values are for the player making the move: 1 for a win,
+// -1 for a loss.
+type QLearningPlayer struct {
+	Width  int
+	Height int
+
+	// Action values by position key, one per column
+	Q map[uint64][]float64
+
+	// Learning rate
+	Alpha float64
+
+	// Discount for each move further away a result is
+	Gamma float64
+
+	// Chance of a random move while training
+	Epsilon float64
+
+	Rand *rand.Rand
+}
+
+func NewQLearningPlayer(width int, height int) *QLearningPlayer {
+	return &QLearningPlayer{
+		Width:   width,
+		Height:  height,
+		Q:       make(map[uint64][]float64),
+		Alpha:   0.3,
+		Gamma:   0.95,
+		Epsilon: 0.2,
+		Rand:    rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+// Number of positions with values
+func (player *QLearningPlayer) States() int {
+	return len(player.Q)
+}
+
+func (player *QLearningPlayer) values(pos tablebase.Position) []float64 {
+	key := pos.Key()
+	values, ok := player.Q[key]
+	if !ok {
+		values = make([]float64, pos.Width)
+		player.Q[key] = values
+	}
+	return values
+}
+
+// Best known move, choosing at random among equals
+func (player *QLearningPlayer) Move(pos tablebase.Position) int {
+	values := player.Q[pos.Key()]
+
+	var best []int
+	for _, col := range validMoves(pos) {
+		switch {
+		case len(best) == 0 || (values != nil && values[col] > values[best[0]]):
+			best = []int{col}
+		case values == nil || values[col] == values[best[0]]:
+			best = append(best, col)
+		}
+	}
+	return best[player.Rand.Intn(len(best))]
+}
+
+// Plays on the 7x6 board, for a table trained at that size
+func (player *QLearningPlayer) MakeMove(board *game.Board) int {
+	move := player.Move(tablebase.FromBoard(board))
+	board.MakeMove(move)
+	return move
+}
+
+// Plays one game against itself, updating the value of every move made
+func (player *QLearningPlayer) TrainEpisode() {
+	pos, _ := tablebase.NewPosition(player.Width, player.Height)
+
+	for {
+		col := player.Move(pos)
+		if player.Rand.Float64() < player.Epsilon {
+			valid := validMoves(pos)
+			col = valid[player.Rand.Intn(len(valid))]
+		}
+
+		// The value after a move is the negative of the opponent's best
+		// reply, since what's good for them is bad for the mover
+		target := 0.0
+		over := true
+		next := pos.Play(col)
+		switch {
+		case pos.IsWinningMove(col):
+			target = 1
+		case next.Full():
+			target = 0
+		default:
+			over = false
+			replies := player.values(next)
+			best := -1.0
+			for _, reply := range validMoves(next) {
+				best = max(best, replies[reply])
+			}
+			target = -player.Gamma * best
+		}
+
+		values := player.values(pos)
+		values[col] += player.Alpha * (target - values[col])
+
+		if over {
+			return
+		}
+		pos = next
+	}
+}
+
+// Plays the learner without exploring against an opponent, each going
+// first in turn. Returns the share of games won and drawn.
+func (player *QLearningPlayer) Evaluate(opponent Policy, games int) (float64, float64) {
+	wins, draws := 0, 0
+	for i := 0; i < games; i++ {
+		pos, _ := tablebase.NewPosition(player.Width, player.Height)
+		learnerToMove := i%2 == 0
+
+		for {
+			var col int
+			if learnerToMove {
+				col = player.Move(pos)
+			} else {
+				col = opponent.Move(pos)
+			}
+
+			if pos.IsWinningMove(col) {
+				if learnerToMove {
+					wins++
+				}
+				break
+			}
+			if pos = pos.Play(col); pos.Full() {
+				draws++
+				break
+			}
+			learnerToMove = !learnerToMove
+		}
+	}
+	return float64(wins) / float64(games), float64(draws) / float64(games)
+}
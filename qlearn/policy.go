@@ -0,0 +1,106 @@
+// Package qlearn learns to play Connect Four on small boards with a table
+// of action values, and measures how it does as it learns.
+package qlearn
+
+import (
+	"FinalProject/game"
+	"FinalProject/tablebase"
+	"math/rand"
+)
+
+// Picks moves on a board of any size
+type Policy interface {
+	Move(pos tablebase.Position) int
+}
+
+func validMoves(pos tablebase.Position) []int {
+	var valid []int
+	for col := 0; col < pos.Width; col++ {
+		if pos.CanPlay(col) {
+			valid = append(valid, col)
+		}
+	}
+	return valid
+}
+
+// Plays any legal move, like game.RandomPlayer
+type RandomPolicy struct {
+	Rand *rand.Rand
+}
+
+func (policy *RandomPolicy) Move(pos tablebase.Position) int {
+	valid := validMoves(pos)
+	return valid[policy.Rand.Intn(len(valid))]
+}
+
+// Searches Depth moves ahead for wins and losses, choosing at random among
+// equal moves. Stands in for game.SmartPlayer on boards it can't play.
+type SearchPolicy struct {
+	Depth int
+	Rand  *rand.Rand
+}
+
+func (policy *SearchPolicy) Move(pos tablebase.Position) int {
+	var best []int
+	bestValue := -2
+	for _, col := range validMoves(pos) {
+		value := negamaxMove(pos, col, policy.Depth-1)
+		if value > bestValue {
+			best, bestValue = []int{col}, value
+		} else if value == bestValue {
+			best = append(best, col)
+		}
+	}
+	return best[policy.Rand.Intn(len(best))]
+}
+
+// Value of playing col for the side to move: 1 for a forced win, -1 for a
+// forced loss, 0 otherwise
+func negamaxMove(pos tablebase.Position, col int, depth int) int {
+	if pos.IsWinningMove(col) {
+		return 1
+	}
+	next := pos.Play(col)
+	if next.Full() || depth <= 0 {
+		return 0
+	}
+
+	best := -1
+	for _, reply := range validMoves(next) {
+		best = game.Max(best, negamaxMove(next, reply, depth-1))
+		if best == 1 {
+			break
+		}
+	}
+	return -best
+}
+
+// Plays a game.Player on 7x6 positions
+type PlayerPolicy struct {
+	Player game.Player
+}
+
+func (policy *PlayerPolicy) Move(pos tablebase.Position) int {
+	board, err := pos.Board()
+	if err != nil {
+		panic(err)
+	}
+
+	// Players that play by piece need to be told which one is to move
+	if smart, ok := policy.Player.(*game.SmartPlayer); ok {
+		smart.Piece = game.Tokens[board.WhoseTurn]
+	}
+	return policy.Player.MakeMove(board)
+}
+
+// Returns the policies to measure a learner against: a random player and a
+// depth limited search. On the full board they're game.RandomPlayer and
+// game.SmartPlayer with its heuristic; on a smaller one, which SmartPlayer
+// can't play, RandomPolicy and SearchPolicy, which only sees wins and
+// losses.
+func Opponents(width int, height int, depth int, rng *rand.Rand) (random Policy, search Policy) {
+	if width == game.NumCols && height == game.NumRows {
+		return &PlayerPolicy{Player: &game.RandomPlayer{}}, &PlayerPolicy{Player: game.NewSmartPlayer(0, depth)}
+	}
+	return &RandomPolicy{Rand: rng}, &SearchPolicy{Depth: depth, Rand: rng}
+}
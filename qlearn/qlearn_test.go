@@ -0,0 +1,73 @@
+package qlearn
+
+import (
+	"FinalProject/game"
+	"FinalProject/tablebase"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+func TestSearchPolicy(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	policy := &SearchPolicy{Depth: 2, Rand: rng}
+
+	// Take the win
+	pos, _ := tablebase.ParseMoves(5, 4, "121212")
+	if move := policy.Move(pos); move != 0 {
+		t.Errorf("Should win in column 1, played %d", move+1)
+	}
+
+	// Block the win
+	pos, _ = tablebase.ParseMoves(5, 4, "12121")
+	if move := policy.Move(pos); move != 0 {
+		t.Errorf("Should block column 1, played %d", move+1)
+	}
+}
+
+func TestPlayerPolicy(t *testing.T) {
+	pos, _ := tablebase.ParseMoves(7, 6, "1212156")
+	policy := &PlayerPolicy{Player: game.NewSmartPlayer(0, 2)}
+
+	// O has to block, and the player is told it's O
+	if move := policy.Move(pos); move != 0 {
+		t.Errorf("SmartPlayer should block column 1, played %d", move+1)
+	}
+}
+
+func TestLearnsToWin(t *testing.T) {
+	player := NewQLearningPlayer(4, 4)
+	player.Rand = rand.New(rand.NewSource(1))
+	random := &RandomPolicy{Rand: rand.New(rand.NewSource(2))}
+
+	before, _ := player.Evaluate(random, 200)
+	for i := 0; i < 5000; i++ {
+		player.TrainEpisode()
+	}
+	after, _ := player.Evaluate(random, 200)
+
+	if after <= before {
+		t.Errorf("Training should win more against random play: %.2f before, %.2f after", before, after)
+	}
+}
+
+func TestTrainCurve(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	player := NewQLearningPlayer(4, 4)
+	player.Rand = rng
+	random, search := Opponents(4, 4, 2, rng)
+
+	curve := Train(player, 100, 50, 10, random, search, nil)
+	if len(curve) != 3 || curve[2].Episode != 100 || curve[2].States == 0 {
+		t.Fatalf("Expected points at 0, 50 and 100 episodes: %+v", curve)
+	}
+
+	var sb strings.Builder
+	if err := WriteCSV(&sb, curve); err != nil {
+		t.Fatal(err)
+	}
+	rows := strings.Split(strings.TrimSpace(sb.String()), "\n")
+	if len(rows) != 4 || rows[0] != "episode,states,random_win,random_draw,search_win,search_draw" {
+		t.Errorf("Unexpected CSV:\n%s", sb.String())
+	}
+}
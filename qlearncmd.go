@@ -0,0 +1,53 @@
+package main
+
+import (
+	"FinalProject/qlearn"
+	"flag"
+	"fmt"
+	"math/rand"
+	"os"
+	"time"
+)
+
+// Trains a Q-learning player on a small board and writes its learning curve
+// as CSV
+func qlearnCommand(args []string) {
+	flags := flag.NewFlagSet("qlearn", flag.ExitOnError)
+	size := flags.String("size", "5x4", "board size as columns x rows")
+	episodes := flags.Int("episodes", 100000, "self-play games to train on")
+	alpha := flags.Float64("alpha", 0.3, "learning rate")
+	gamma := flags.Float64("gamma", 0.95, "discount per move")
+	epsilon := flags.Float64("epsilon", 0.2, "chance of a random move while training")
+	every := flags.Int("every", 5000, "measure win rates every this many episodes")
+	games := flags.Int("games", 100, "games against each opponent per measurement")
+	depth := flags.Int("depth", 2, "depth of the search opponent")
+	out := flags.String("csv", "qlearn.csv", "file for the learning curve")
+	flags.Parse(args)
+
+	var width, height int
+	if _, err := fmt.Sscanf(*size, "%dx%d", &width, &height); err != nil {
+		exitIf(fmt.Errorf("bad size %q", *size))
+	}
+
+	player := qlearn.NewQLearningPlayer(width, height)
+	player.Alpha = *alpha
+	player.Gamma = *gamma
+	player.Epsilon = *epsilon
+
+	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
+	random, search := qlearn.Opponents(width, height, *depth, rng)
+
+	curve := qlearn.Train(player, *episodes, *every, *games, random, search, func(point qlearn.Point) {
+		fmt.Printf("%8d episodes %9d states  vs random %5.1f%% won  vs search %5.1f%% won %5.1f%% drawn\n",
+			point.Episode, point.States, 100*point.RandomWin, 100*point.SearchWin, 100*point.SearchDraw)
+	})
+
+	f, err := os.Create(*out)
+	exitIf(err)
+	if err := qlearn.WriteCSV(f, curve); err != nil {
+		f.Close()
+		exitIf(err)
+	}
+	exitIf(f.Close())
+	fmt.Printf("Wrote %s\n", *out)
+}
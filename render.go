@@ -0,0 +1,54 @@
+package main
+
+import (
+	"FinalProject/game"
+	"flag"
+	"fmt"
+	"os"
+)
+
+// Draws the position after a move string as an SVG image
+func renderCommand(args []string) {
+	flags := flag.NewFlagSet("render", flag.ExitOnError)
+	moves := flags.String("moves", "", "columns played, e.g. 4453")
+	first := flags.String("first", "x", "token that moved first (x or o)")
+	out := flags.String("out", "board.svg", "file to write, or - for stdout")
+	size := flags.Int("size", 60, "cell size in pixels")
+	last := flags.Bool("last", true, "mark the last move")
+	win := flags.Bool("win", true, "mark the winning line")
+	suggest := flags.Int("suggest", 0, "mark the engine's move searched to this depth (0 for none)")
+	flags.Parse(args)
+
+	firstIdx, err := tokenIndex(*first)
+	exitIf(err)
+	cols, err := game.ParseMoves(*moves)
+	exitIf(err)
+
+	board := game.NewBoard()
+	board.WhoseTurn = firstIdx
+	exitIf(board.PlayMoves(cols))
+
+	opts := game.SVGOptions{CellSize: *size, MarkWinningLine: *win}
+	if *last && len(cols) > 0 {
+		opts.MarkLastMove = true
+		opts.LastMove = cols[len(cols)-1]
+	}
+	if *suggest > 0 && !board.CheckEndGame() {
+		opts.MarkSuggestion = true
+		opts.Suggestion = game.Analyze(board, *suggest).Move
+	}
+
+	if *out == "-" {
+		exitIf(board.RenderSVG(os.Stdout, opts))
+		return
+	}
+
+	f, err := os.Create(*out)
+	exitIf(err)
+	if err := board.RenderSVG(f, opts); err != nil {
+		f.Close()
+		exitIf(err)
+	}
+	exitIf(f.Close())
+	fmt.Printf("Wrote %s\n", *out)
+}
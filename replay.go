@@ -0,0 +1,120 @@
+package main
+
+import (
+	"FinalProject/game"
+	"FinalProject/terminal"
+	"bufio"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Steps through a saved game with the engine's view of every move
+func replayCommand(args []string) {
+	flags := flag.NewFlagSet("replay", flag.ExitOnError)
+	depth := flags.Int("depth", 4, "search depth used to evaluate each position")
+	threshold := flags.Int("threshold", 50, "evaluation swing that makes a move a blunder")
+	ply := flags.Int("ply", -1, "print the game up to this ply and stop")
+	flags.Usage = func() {
+		fmt.Fprintln(os.Stderr, "usage: replay [flags] game.txt")
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+
+	if flags.NArg() != 1 {
+		flags.Usage()
+		os.Exit(2)
+	}
+
+	record := loadRecord(flags.Arg(0))
+	fmt.Printf("X: %s  O: %s  Result: %s\n", record.Players[0], record.Players[1], record.Result)
+	fmt.Printf("Evaluating %d moves at depth %d...\n", len(record.Moves), *depth)
+
+	reviews, err := game.Review(record, *depth, *threshold)
+	exitIf(err)
+
+	blunders := 0
+	for _, review := range reviews {
+		if review.Blunder {
+			blunders++
+		}
+	}
+	fmt.Printf("%d blunders (swing over %d)\n", blunders, *threshold)
+
+	// Without a terminal to step through, print everything
+	if *ply >= 0 || !terminal.IsTerminal(os.Stdin) {
+		end := len(reviews)
+		if *ply >= 0 && *ply < end {
+			end = *ply
+		}
+		for i := 0; i <= end; i++ {
+			showPly(record, reviews, i)
+		}
+		return
+	}
+
+	reader := bufio.NewReader(os.Stdin)
+	current := 0
+	for {
+		showPly(record, reviews, current)
+		fmt.Print("[n]ext, [p]rev, [b]lunder, [g]o <ply>, [q]uit: ")
+
+		text, err := reader.ReadString('\n')
+		if err != nil {
+			return
+		}
+
+		command, arg, _ := strings.Cut(strings.TrimSpace(text), " ")
+		switch command {
+		case "", "n":
+			current = game.Min(current+1, len(reviews))
+		case "p":
+			current = game.Max(current-1, 0)
+		case "b":
+			// Jump to the next blunder, wrapping around
+			for i := 1; i <= len(reviews); i++ {
+				idx := (current + i) % (len(reviews) + 1)
+				if idx > 0 && reviews[idx-1].Blunder {
+					current = idx
+					break
+				}
+			}
+		case "g":
+			if n, err := strconv.Atoi(arg); err == nil {
+				current = game.Max(0, game.Min(n, len(reviews)))
+			}
+		case "q":
+			return
+		}
+	}
+}
+
+// Prints the board after the first n moves and what the engine made of
+// the last of them
+func showPly(record *game.Record, reviews []game.PlyReview, n int) {
+	board, err := record.BoardAt(n)
+	exitIf(err)
+	board.Print()
+
+	if n == 0 {
+		fmt.Printf("Start: %c to move\n\n", game.Tokens[record.First])
+		return
+	}
+
+	review := reviews[n-1]
+	fmt.Printf("Ply %d/%d: %c played %d; eval %+d -> %+d", review.Ply, len(reviews), review.Player, review.Column+1, review.Before, review.After)
+	if move := record.Moves[n-1]; move.Eval != nil {
+		fmt.Printf(" (recorded %+d)", *move.Eval)
+	}
+	fmt.Println()
+
+	if review.Blunder {
+		fmt.Printf("?? Blunder: lost %d; the engine preferred %d\n", review.Swing, review.Best+1)
+	}
+	if comment := record.Moves[n-1].Comment; comment != "" {
+		fmt.Println(comment)
+	}
+	fmt.Println()
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"FinalProject/spectate"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+// Runs a batch of games at once, optionally streaming them to spectators
+func simulateCommand(args []string) {
+	flags := flag.NewFlagSet("simulate", flag.ExitOnError)
+	numReps := flags.Int("n", 100, "number of games")
+	xSpec := flags.String("x", "smart:1", "player using X: "+playerKinds)
+	oSpec := flags.String("o", "smart:1", "player using O: "+playerKinds)
+	addr := flags.String("spectate", "", "serve a WebSocket at ws://<addr>/ws for watching games")
+	delay := flags.Duration("delay", 0, "pause after each move")
+	flags.Parse(args)
+
+	sim := &simulation{numReps: *numReps, players: [2]string{*xSpec, *oSpec}, delay: *delay}
+	for i, spec := range sim.players {
+		player, err := newPlayer(spec, i, nil)
+		exitIf(err)
+		closePlayers(player)
+	}
+
+	if *addr != "" {
+		sim.hub = spectate.NewHub()
+
+		mux := http.NewServeMux()
+		mux.Handle("/ws", sim.hub)
+		go func() { exitIf(http.ListenAndServe(*addr, mux)) }()
+
+		fmt.Printf("Spectate at ws://%s/ws (games 1-%d, pick some with ?games=1,2)\n", *addr, *numReps)
+	}
+
+	sim.run()
+	fmt.Println("Simulation complete.")
+}
+
+// Prints the moves streamed by a simulation's spectate WebSocket
+func watchCommand(args []string) {
+	flags := flag.NewFlagSet("watch", flag.ExitOnError)
+	games := flags.String("games", "", "comma separated games to watch (default all)")
+	flags.Usage = func() {
+		fmt.Fprintln(os.Stderr, "usage: watch [-games 1,2] [ws://host:port/ws]")
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+
+	url := "ws://localhost:8090/ws"
+	if flags.NArg() > 0 {
+		url = flags.Arg(0)
+	}
+	if *games != "" {
+		url += "?games=" + strings.ReplaceAll(*games, " ", "")
+	}
+
+	conn, err := spectate.Dial(url)
+	exitIf(err)
+	defer conn.Close()
+
+	for {
+		msg, err := conn.ReadText()
+		if err != nil {
+			if err != spectate.ErrClosed {
+				fmt.Fprintln(os.Stderr, err)
+			}
+			return
+		}
+
+		var e spectate.Event
+		if json.Unmarshal(msg, &e) != nil {
+			continue
+		}
+
+		stamp := time.Now().Format("15:04:05")
+		switch e.Type {
+		case "start":
+			fmt.Printf("%s game %d: started, %s to move\n", stamp, e.Game, e.Player)
+		case "move":
+			line := fmt.Sprintf("%s game %d ply %d: %s plays %d", stamp, e.Game, e.Ply, e.Player, e.Column)
+			if e.Evaluation != nil {
+				line += fmt.Sprintf(" (eval %d)", *e.Evaluation)
+			}
+			fmt.Println(line)
+		case "end":
+			result := "tie"
+			if e.Winner != "" {
+				result = e.Winner + " wins"
+			}
+			fmt.Printf("%s game %d: %s after %d moves\n", stamp, e.Game, result, e.Ply)
+		}
+	}
+}
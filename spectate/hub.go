@@ -0,0 +1,177 @@
+// Package spectate streams moves from running games to WebSocket clients,
+// so simulations can be watched live from a browser or the watch command.
+//
+// Each message is one JSON Event. Clients can pick games with a query such
+// as /ws?games=1,4,7; without one they get every game.
+package spectate
+
+import (
+	"FinalProject/game"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Events a subscriber can fall behind by before it starts missing them
+const bufferSize = 256
+
+type Event struct {
+	// "start", "move" or "end"
+	Type string `json:"type"`
+
+	Game   int    `json:"game"`
+	Ply    int    `json:"ply"`
+	Column int    `json:"column,omitempty"`
+	Player string `json:"player,omitempty"`
+
+	// Engine evaluation behind the move, for the player who made it
+	Evaluation *int `json:"evaluation,omitempty"`
+
+	Board  string `json:"board"`
+	Winner string `json:"winner,omitempty"`
+}
+
+// Fans events out to every connected spectator
+type Hub struct {
+	mu   sync.Mutex
+	subs map[*subscriber]struct{}
+}
+
+type subscriber struct {
+	events chan Event
+	games  map[int]bool
+}
+
+func NewHub() *Hub {
+	return &Hub{subs: make(map[*subscriber]struct{})}
+}
+
+// Sends e to everyone watching its game. Never blocks: a spectator that
+// can't keep up misses events rather than slowing the games down.
+func (h *Hub) Publish(e Event) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for sub := range h.subs {
+		if sub.games != nil && !sub.games[e.Game] {
+			continue
+		}
+		select {
+		case sub.events <- e:
+		default:
+		}
+	}
+}
+
+// Publishes the start of match and every move after it under gameID. Call
+// before the match is played.
+func (h *Hub) Watch(match *game.Match, gameID int) {
+	h.Publish(Event{
+		Type:   "start",
+		Game:   gameID,
+		Player: string(game.Tokens[match.Board.WhoseTurn]),
+		Board:  match.Board.Encode(),
+	})
+
+	onMove := match.OnMove
+	match.OnMove = func(match *game.Match, playerIdx int, move int) {
+		e := Event{
+			Type:   "move",
+			Game:   gameID,
+			Ply:    len(match.Moves),
+			Column: move + 1,
+			Player: string(game.Tokens[playerIdx]),
+			Board:  match.Board.Encode(),
+		}
+		if player, ok := match.Players[playerIdx].(game.Evaluating); ok {
+			if value, ok := player.LastEvaluation(); ok {
+				e.Evaluation = &value
+			}
+		}
+		h.Publish(e)
+
+		if match.Board.CheckEndGame() {
+			e := Event{Type: "end", Game: gameID, Ply: len(match.Moves), Board: match.Board.Encode()}
+			if match.Board.Winner != ' ' {
+				e.Winner = string(match.Board.Winner)
+			}
+			h.Publish(e)
+		}
+
+		if onMove != nil {
+			onMove(match, playerIdx, move)
+		}
+	}
+}
+
+// Upgrades the request to a WebSocket and streams events until the client
+// goes away
+func (h *Hub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	games, err := parseGames(r.URL.Query().Get("games"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	conn, err := upgrade(w, r)
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	sub := &subscriber{events: make(chan Event, bufferSize), games: games}
+	h.mu.Lock()
+	h.subs[sub] = struct{}{}
+	h.mu.Unlock()
+
+	defer func() {
+		h.mu.Lock()
+		delete(h.subs, sub)
+		h.mu.Unlock()
+	}()
+
+	// Spectators don't send anything we need, but reading is how we notice
+	// they've left
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for {
+			if _, err := conn.ReadText(); err != nil {
+				return
+			}
+		}
+	}()
+
+	for {
+		select {
+		case e := <-sub.events:
+			msg, _ := json.Marshal(e)
+			if err := conn.WriteText(msg); err != nil {
+				return
+			}
+		case <-done:
+			return
+		case <-r.Context().Done():
+			return
+		}
+	}
+}
+
+// Parses a comma separated list of game ids. Empty means every game.
+func parseGames(s string) (map[int]bool, error) {
+	if s == "" {
+		return nil, nil
+	}
+
+	games := make(map[int]bool)
+	for _, part := range strings.Split(s, ",") {
+		id, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil {
+			return nil, err
+		}
+		games[id] = true
+	}
+	return games, nil
+}
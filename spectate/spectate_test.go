@@ -0,0 +1,123 @@
+package spectate
+
+import (
+	"FinalProject/game"
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Connects a client and waits until the hub has registered it
+func connect(t *testing.T, h *Hub, query string) *Conn {
+	t.Helper()
+	server := httptest.NewServer(h)
+	t.Cleanup(server.Close)
+
+	conn, err := Dial("ws" + strings.TrimPrefix(server.URL, "http") + "/ws" + query)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	for i := 0; i < 100; i++ {
+		h.mu.Lock()
+		n := len(h.subs)
+		h.mu.Unlock()
+		if n > 0 {
+			return conn
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatal("Hub never registered the client")
+	return nil
+}
+
+func read(t *testing.T, conn *Conn) Event {
+	t.Helper()
+	msg, err := conn.ReadText()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var e Event
+	if err := json.Unmarshal(msg, &e); err != nil {
+		t.Fatal(err)
+	}
+	return e
+}
+
+func TestFiltersGames(t *testing.T) {
+	h := NewHub()
+	conn := connect(t, h, "?games=2,3")
+
+	h.Publish(Event{Type: "move", Game: 1, Ply: 1})
+	h.Publish(Event{Type: "move", Game: 2, Ply: 7})
+
+	if e := read(t, conn); e.Game != 2 || e.Ply != 7 {
+		t.Errorf("Only game 2 should have been sent, got %+v", e)
+	}
+}
+
+func TestWatchMatch(t *testing.T) {
+	h := NewHub()
+	conn := connect(t, h, "")
+
+	match := game.NewMatch(game.NewSmartPlayer(0, 1), &game.RandomPlayer{})
+	h.Watch(match, 5)
+	match.Play()
+
+	if e := read(t, conn); e.Type != "start" || e.Game != 5 || e.Player != "X" {
+		t.Errorf("First event should be the start of game 5: %+v", e)
+	}
+
+	for ply := 1; ply <= len(match.Moves); ply++ {
+		e := read(t, conn)
+		if e.Type != "move" || e.Ply != ply || e.Column != match.Moves[ply-1]+1 {
+			t.Fatalf("Expected move %d, got %+v", ply, e)
+		}
+
+		if (e.Player == "X") != (e.Evaluation != nil) {
+			t.Errorf("Only the smart player's moves should carry an evaluation: %+v", e)
+		}
+	}
+
+	e := read(t, conn)
+	if e.Type != "end" || e.Board != match.Board.Encode() || e.Winner != strings.TrimSpace(string(match.Board.Winner)) {
+		t.Errorf("Last event should be the end of the game: %+v", e)
+	}
+}
+
+func TestWatchEvaluatingPlayer(t *testing.T) {
+	h := NewHub()
+	conn := connect(t, h, "")
+
+	match := game.NewMatch(&game.RandomPlayer{}, game.NewExpectimaxPlayer(2, game.UniformModel{}))
+	h.Watch(match, 1)
+	match.Play()
+
+	read(t, conn)
+	for ply := 1; ply <= len(match.Moves); ply++ {
+		e := read(t, conn)
+		if (e.Player == "O") != (e.Evaluation != nil) {
+			t.Errorf("Should report the expectimax player's evaluations: %+v", e)
+		}
+	}
+}
+
+func TestRejectsPlainHTTP(t *testing.T) {
+	rec := httptest.NewRecorder()
+	NewHub().ServeHTTP(rec, httptest.NewRequest("GET", "/ws", nil))
+
+	if rec.Code != 400 {
+		t.Errorf("Plain GET should be a bad request, got %d", rec.Code)
+	}
+}
+
+func TestAcceptKey(t *testing.T) {
+	// Example from RFC 6455 section 1.3
+	if key := acceptKey("dGhlIHNhbXBsZSBub25jZQ=="); key != "s3pPLMBiTxaQ9kYGzzhZRbK+xOo=" {
+		t.Errorf("Wrong accept key %q", key)
+	}
+}
@@ -0,0 +1,85 @@
+package tablebase
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// A tablebase file is
+//
+//	"C4TB", a version byte, width and height bytes
+//	the number of positions as a uvarint
+//	the keys in order, each as a uvarint of its difference from the last
+//	one byte per position: result in the top two bits, plies below
+//
+// Keys of neighbouring positions are close, so most take two or three
+// bytes.
+const magic = "C4TB"
+
+const version = 1
+
+func (tb *Tablebase) Save(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	bw.WriteString(magic)
+	bw.Write([]byte{version, byte(tb.Width), byte(tb.Height)})
+
+	buf := make([]byte, binary.MaxVarintLen64)
+	bw.Write(buf[:binary.PutUvarint(buf, uint64(len(tb.keys)))])
+
+	var last uint64
+	for _, key := range tb.keys {
+		bw.Write(buf[:binary.PutUvarint(buf, key-last)])
+		last = key
+	}
+	bw.Write(tb.entries)
+	return bw.Flush()
+}
+
+func Load(r io.Reader) (*Tablebase, error) {
+	br := bufio.NewReader(r)
+
+	header := make([]byte, len(magic)+3)
+	if _, err := io.ReadFull(br, header); err != nil {
+		return nil, fmt.Errorf("tablebase: reading header: %v", err)
+	}
+	if string(header[:len(magic)]) != magic {
+		return nil, errors.New("tablebase: not a tablebase file")
+	}
+	if header[len(magic)] != version {
+		return nil, fmt.Errorf("tablebase: unknown version %d", header[len(magic)])
+	}
+
+	width, height := int(header[len(magic)+1]), int(header[len(magic)+2])
+	if _, err := NewPosition(width, height); err != nil {
+		return nil, err
+	}
+	tb := &Tablebase{Width: width, Height: height}
+
+	count, err := binary.ReadUvarint(br)
+	if err != nil {
+		return nil, fmt.Errorf("tablebase: reading size: %v", err)
+	}
+
+	// Don't trust the count for the allocation
+	var last uint64
+	for i := uint64(0); i < count; i++ {
+		delta, err := binary.ReadUvarint(br)
+		if err != nil {
+			return nil, fmt.Errorf("tablebase: reading keys: %v", err)
+		}
+		if i > 0 && delta == 0 {
+			return nil, errors.New("tablebase: keys out of order")
+		}
+		last += delta
+		tb.keys = append(tb.keys, last)
+	}
+
+	tb.entries = make([]byte, len(tb.keys))
+	if _, err := io.ReadFull(br, tb.entries); err != nil {
+		return nil, fmt.Errorf("tablebase: reading entries: %v", err)
+	}
+	return tb, nil
+}
@@ -0,0 +1,199 @@
+// Package tablebase solves Connect Four positions exactly by retrograde
+// analysis and stores the results for lookup during search.
+//
+// A tablebase covers every position reachable from a root: the empty board
+// for small sizes such as 5x4, or a late 7x6 position with few empty cells.
+// The full 7x6 board has far too many positions to generate.
+package tablebase
+
+import (
+	"FinalProject/game"
+	"fmt"
+	"math/bits"
+	"strings"
+)
+
+// A position on a board of up to 64 cells including a spare row above each
+// column. Stones are stored as bitboards with each column taking Height+1
+// bits, bottom first.
+type Position struct {
+	Width  int
+	Height int
+
+	// Stones of the side to move
+	current uint64
+
+	// Stones of both sides
+	mask uint64
+
+	moves int
+}
+
+// Returns an empty board of the given size
+func NewPosition(width int, height int) (Position, error) {
+	if width < 1 || height < 1 || width*(height+1) > 64 {
+		return Position{}, fmt.Errorf("tablebase: can't fit a %dx%d board in a bitboard", width, height)
+	}
+	return Position{Width: width, Height: height}, nil
+}
+
+// Converts a 7x6 board
+func FromBoard(board *game.Board) Position {
+	pos := Position{Width: game.NumCols, Height: game.NumRows}
+	me := game.Tokens[board.WhoseTurn]
+
+	for c := 0; c < game.NumCols; c++ {
+		for r := 0; r < game.NumRows; r++ {
+			switch board.At(c, r) {
+			case ' ':
+				continue
+			case me:
+				pos.current |= pos.bit(c, r)
+			}
+			pos.mask |= pos.bit(c, r)
+			pos.moves++
+		}
+	}
+	return pos
+}
+
+// Converts a 7x6 position back to a board, taking X to have moved first
+func (pos Position) Board() (*game.Board, error) {
+	if pos.Width != game.NumCols || pos.Height != game.NumRows {
+		return nil, fmt.Errorf("tablebase: a %dx%d position isn't a board", pos.Width, pos.Height)
+	}
+
+	toMove, other := game.Tokens[pos.moves%2], game.Tokens[(pos.moves+1)%2]
+	var rows []string
+	for r := pos.Height - 1; r >= 0; r-- {
+		row := make([]byte, pos.Width)
+		for c := 0; c < pos.Width; c++ {
+			switch {
+			case pos.current&pos.bit(c, r) != 0:
+				row[c] = toMove
+			case pos.mask&pos.bit(c, r) != 0:
+				row[c] = other
+			default:
+				row[c] = '.'
+			}
+		}
+		rows = append(rows, string(row))
+	}
+	return game.DecodeBoard(strings.Join(rows, "/") + " " + string(toMove))
+}
+
+// Plays a move string such as "4453" from the empty board
+func ParseMoves(width int, height int, moves string) (Position, error) {
+	pos, err := NewPosition(width, height)
+	if err != nil {
+		return pos, err
+	}
+
+	for i := 0; i < len(moves); i++ {
+		col := int(moves[i] - '1')
+		if col < 0 || col >= width || !pos.CanPlay(col) {
+			return pos, fmt.Errorf("tablebase: move %d is illegal (%q)", i+1, moves[i])
+		}
+		if pos.IsWinningMove(col) && i < len(moves)-1 {
+			return pos, fmt.Errorf("tablebase: move %d played after the game ended", i+2)
+		}
+		pos = pos.Play(col)
+	}
+	return pos, nil
+}
+
+// Number of stones on the board
+func (pos Position) Moves() int {
+	return pos.moves
+}
+
+// Whether every cell is taken
+func (pos Position) Full() bool {
+	return pos.moves == pos.Width*pos.Height
+}
+
+// Whether the game has ended, with a win for the side that just moved or a
+// full board
+func (pos Position) Over() bool {
+	return pos.aligned(pos.current^pos.mask) || pos.Full()
+}
+
+func (pos Position) CanPlay(col int) bool {
+	return pos.mask&pos.bit(col, pos.Height-1) == 0
+}
+
+// Whether the side to move wins by playing col
+func (pos Position) IsWinningMove(col int) bool {
+	stones := pos.current | ((pos.mask + pos.bit(col, 0)) & pos.columnMask(col))
+	return pos.aligned(stones)
+}
+
+// Returns the position after the side to move plays col
+func (pos Position) Play(col int) Position {
+	pos.current ^= pos.mask
+	pos.mask |= pos.mask + pos.bit(col, 0)
+	pos.moves++
+	return pos
+}
+
+// A number unique to the position among positions of its size. Each column
+// holds the side to move's stones under a 1 just above the top stone.
+func (pos Position) Key() uint64 {
+	return pos.current + pos.mask + pos.bottom()
+}
+
+// Returns the position whose key is key
+func fromKey(width int, height int, key uint64) Position {
+	pos := Position{Width: width, Height: height}
+	for c := 0; c < width; c++ {
+		column := (key >> (c * (height + 1))) & (1<<(height+1) - 1)
+		h := bits.Len64(column) - 1
+
+		pos.mask |= (1<<h - 1) << (c * (height + 1))
+		pos.current |= (column - 1<<h) << (c * (height + 1))
+		pos.moves += h
+	}
+	return pos
+}
+
+// The key of the position or its mirror image, whichever is lower. A
+// position and its mirror have the same value.
+func (pos Position) canonicalKey() uint64 {
+	key := pos.Key()
+	var mirror uint64
+	size := uint(pos.Height + 1)
+	for c := 0; c < pos.Width; c++ {
+		column := (key >> (uint(c) * size)) & (1<<size - 1)
+		mirror |= column << (uint(pos.Width-1-c) * size)
+	}
+	return min(key, mirror)
+}
+
+func (pos Position) bit(col int, row int) uint64 {
+	return 1 << (col*(pos.Height+1) + row)
+}
+
+func (pos Position) bottom() uint64 {
+	var b uint64
+	for c := 0; c < pos.Width; c++ {
+		b |= pos.bit(c, 0)
+	}
+	return b
+}
+
+func (pos Position) columnMask(col int) uint64 {
+	return (1<<pos.Height - 1) << (col * (pos.Height + 1))
+}
+
+// Whether stones hold four in a row. The spare row keeps lines from
+// wrapping between columns.
+func (pos Position) aligned(stones uint64) bool {
+	size := pos.Height + 1
+	for _, shift := range []int{1, size - 1, size, size + 1} {
+		pairs := stones & (stones >> shift)
+		if pairs&(pairs>>(2*shift)) != 0 {
+			return true
+		}
+	}
+	return false
+}
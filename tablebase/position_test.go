@@ -0,0 +1,113 @@
+package tablebase
+
+import (
+	"FinalProject/game"
+	"math/rand"
+	"testing"
+)
+
+func TestKeyRoundTrip(t *testing.T) {
+	pos, err := ParseMoves(7, 6, "4453321")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	again := fromKey(7, 6, pos.Key())
+	if again != pos {
+		t.Errorf("Position should survive its key: %+v %+v", pos, again)
+	}
+}
+
+func TestMirrorKey(t *testing.T) {
+	left, _ := ParseMoves(5, 4, "12")
+	right, _ := ParseMoves(5, 4, "54")
+
+	if left.Key() == right.Key() || left.canonicalKey() != right.canonicalKey() {
+		t.Error("Mirror images should share a canonical key")
+	}
+}
+
+func TestWinningMove(t *testing.T) {
+	tests := []struct {
+		moves string
+		col   int
+	}{
+		{"121212", 0},     // vertical
+		{"112233", 3},     // horizontal
+		{"1223334444", 0}, // not a win
+	}
+
+	for i, test := range tests {
+		pos, err := ParseMoves(7, 6, test.moves)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := pos.IsWinningMove(test.col); got != (i < 2) {
+			t.Errorf("%s then %d: expected win %v", test.moves, test.col+1, i < 2)
+		}
+	}
+}
+
+// Random games should end when the game package says they do
+func TestOverMatchesBoard(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 200; i++ {
+		board := game.NewBoard()
+		pos, _ := NewPosition(game.NumCols, game.NumRows)
+
+		for !board.CheckEndGame() {
+			col := rng.Intn(game.NumCols)
+			if !board.IsValidMove(col) {
+				continue
+			}
+			if pos.Over() {
+				t.Fatalf("Position over before the board: %x", pos.Key())
+			}
+			board.MakeMove(col)
+			pos = pos.Play(col)
+		}
+
+		if !pos.Over() {
+			t.Fatalf("Board over before the position: %x", pos.Key())
+		}
+	}
+}
+
+func TestFromBoard(t *testing.T) {
+	board := game.NewBoard()
+	board.PlayMoves([]int{3, 3, 4, 2})
+
+	pos, _ := ParseMoves(7, 6, "4453")
+	if FromBoard(board) != pos {
+		t.Errorf("Board should convert to the same position as its moves")
+	}
+}
+
+func TestToBoard(t *testing.T) {
+	pos, _ := ParseMoves(7, 6, "44536")
+	board, err := pos.Board()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if board.At(3, 1) != 'O' || board.At(5, 0) != 'X' || board.WhoseTurn != 1 || FromBoard(board) != pos {
+		t.Errorf("Position should convert back to its board:\n%s", board.Encode())
+	}
+
+	small, _ := NewPosition(5, 4)
+	if _, err := small.Board(); err == nil {
+		t.Error("Only 7x6 positions can be boards")
+	}
+}
+
+func TestParseMovesErrors(t *testing.T) {
+	for _, moves := range []string{"8", "1111111", "12121212"} {
+		if _, err := ParseMoves(7, 6, moves); err == nil {
+			t.Errorf("Should reject %q", moves)
+		}
+	}
+
+	if _, err := NewPosition(8, 8); err == nil {
+		t.Error("8x8 doesn't fit in 64 bits with the spare row")
+	}
+}
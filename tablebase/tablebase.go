@@ -0,0 +1,277 @@
+package tablebase
+
+import (
+	"FinalProject/game"
+	"fmt"
+	"sort"
+)
+
+// Outcome with perfect play for the side to move
+type Result byte
+
+const (
+	Draw Result = iota
+	Win
+	Loss
+)
+
+func (result Result) String() string {
+	switch result {
+	case Win:
+		return "win"
+	case Loss:
+		return "loss"
+	}
+	return "draw"
+}
+
+type Entry struct {
+	Result Result
+
+	// Moves left in the game with perfect play. The winner ends it as soon
+	// as they can and the loser holds out as long as they can.
+	Plies int
+}
+
+// Entries are stored as a byte each: the result in the top two bits and the
+// plies below
+func (entry Entry) pack() byte {
+	return byte(entry.Result)<<6 | byte(entry.Plies)
+}
+
+func unpack(b byte) Entry {
+	return Entry{Result: Result(b >> 6), Plies: int(b & 0x3f)}
+}
+
+// Exact results for every unfinished position reachable from a root
+type Tablebase struct {
+	Width  int
+	Height int
+
+	// Canonical keys in order, and the entry for each
+	keys    []uint64
+	entries []byte
+}
+
+// Number of positions stored
+func (tb *Tablebase) Len() int {
+	return len(tb.keys)
+}
+
+// Returns the entry for a position, or false if it isn't stored. Finished
+// positions are never stored.
+func (tb *Tablebase) Lookup(pos Position) (Entry, bool) {
+	if pos.Width != tb.Width || pos.Height != tb.Height {
+		return Entry{}, false
+	}
+
+	key := pos.canonicalKey()
+	i := sort.Search(len(tb.keys), func(i int) bool { return tb.keys[i] >= key })
+	if i == len(tb.keys) || tb.keys[i] != key {
+		return Entry{}, false
+	}
+	return unpack(tb.entries[i]), true
+}
+
+// Value of a win with no plies left. It's far above any heuristic score
+// but small enough that a float64, which the search decays values in,
+// still tells wins a ply apart.
+const WinScore = 1 << 40
+
+// Gives the exact value of stored 7x6 boards for SmartPlayer. Wins and
+// losses score WinScore less the plies left, so a quicker win and a slower
+// loss score higher.
+func (tb *Tablebase) Probe(board *game.Board) (int, bool) {
+	if tb.Width != game.NumCols || tb.Height != game.NumRows {
+		return 0, false
+	}
+
+	entry, ok := tb.Lookup(FromBoard(board))
+	if !ok {
+		return 0, false
+	}
+
+	switch entry.Result {
+	case Win:
+		return WinScore - entry.Plies, true
+	case Loss:
+		return -(WinScore - entry.Plies), true
+	}
+	return 0, true
+}
+
+// Returns the result of each legal move for the player making it, indexed
+// by column. Moves that aren't legal or lead out of the tablebase are
+// missing.
+func (tb *Tablebase) Moves(pos Position) map[int]Entry {
+	results := make(map[int]Entry)
+	for col := 0; col < pos.Width; col++ {
+		if !pos.CanPlay(col) {
+			continue
+		}
+		if entry, ok := tb.evalMove(pos, col, tb.Lookup); ok {
+			results[col] = entry
+		}
+	}
+	return results
+}
+
+// Returns the result of playing col for the player making it, using lookup
+// for the position after
+func (tb *Tablebase) evalMove(pos Position, col int, lookup func(Position) (Entry, bool)) (Entry, bool) {
+	if pos.IsWinningMove(col) {
+		return Entry{Result: Win, Plies: 1}, true
+	}
+
+	next := pos.Play(col)
+	if next.Full() {
+		return Entry{Result: Draw, Plies: 1}, true
+	}
+
+	entry, ok := lookup(next)
+	if !ok {
+		return Entry{}, false
+	}
+
+	switch entry.Result {
+	case Win:
+		entry.Result = Loss
+	case Loss:
+		entry.Result = Win
+	}
+	entry.Plies++
+	return entry, true
+}
+
+// Works out every position reachable from root, one layer of moves at a
+// time from the last back to the root. Stops with an error if there would
+// be more than limit positions. progress, if not nil, is called as each
+// layer is found and then solved.
+func Generate(root Position, limit int, progress func(stage string, ply int, positions int)) (*Tablebase, error) {
+	if root.Over() {
+		return nil, fmt.Errorf("tablebase: the game is already over")
+	}
+
+	// Find the unfinished positions after each number of moves
+	var layers [][]uint64
+	layer := []uint64{root.canonicalKey()}
+	total := 0
+	for len(layer) > 0 {
+		total += len(layer)
+		if total > limit {
+			return nil, fmt.Errorf("tablebase: more than %d positions", limit)
+		}
+		layers = append(layers, layer)
+		if progress != nil {
+			progress("found", root.moves+len(layers)-1, len(layer))
+		}
+
+		var next []uint64
+		for _, key := range layer {
+			pos := fromKey(root.Width, root.Height, key)
+			for col := 0; col < pos.Width; col++ {
+				if !pos.CanPlay(col) || pos.IsWinningMove(col) {
+					continue
+				}
+				if child := pos.Play(col); !child.Full() {
+					next = append(next, child.canonicalKey())
+				}
+			}
+		}
+		layer = dedupe(next)
+	}
+
+	// Solve from the last layer back to the root
+	tb := &Tablebase{Width: root.Width, Height: root.Height}
+	solved := make([][]byte, len(layers))
+	for i := len(layers) - 1; i >= 0; i-- {
+		// Children are all in the layer after
+		var after *Tablebase
+		if i+1 < len(layers) {
+			after = &Tablebase{Width: root.Width, Height: root.Height, keys: layers[i+1], entries: solved[i+1]}
+		}
+		lookup := func(pos Position) (Entry, bool) {
+			if after == nil {
+				return Entry{}, false
+			}
+			return after.Lookup(pos)
+		}
+
+		solved[i] = make([]byte, len(layers[i]))
+		for j, key := range layers[i] {
+			pos := fromKey(root.Width, root.Height, key)
+			solved[i][j] = tb.solve(pos, lookup).pack()
+		}
+		if progress != nil {
+			progress("solved", root.moves+i, len(layers[i]))
+		}
+	}
+
+	// Merge the layers into one sorted table
+	for i := range layers {
+		tb.keys = append(tb.keys, layers[i]...)
+		tb.entries = append(tb.entries, solved[i]...)
+	}
+	sort.Sort(byKey{tb})
+	return tb, nil
+}
+
+// Picks the quickest win, else a draw, else the slowest loss
+func (tb *Tablebase) solve(pos Position, lookup func(Position) (Entry, bool)) Entry {
+	best := Entry{Result: Loss, Plies: -1}
+	for col := 0; col < pos.Width; col++ {
+		if !pos.CanPlay(col) {
+			continue
+		}
+		entry, ok := tb.evalMove(pos, col, lookup)
+		if !ok {
+			panic(fmt.Sprintf("tablebase: child of %x missing", pos.Key()))
+		}
+		if better(entry, best) {
+			best = entry
+		}
+	}
+	return best
+}
+
+func better(a Entry, b Entry) bool {
+	rank := func(e Entry) int {
+		switch e.Result {
+		case Win:
+			return 2
+		case Draw:
+			return 1
+		}
+		return 0
+	}
+
+	if rank(a) != rank(b) {
+		return rank(a) > rank(b)
+	}
+	if a.Result == Win {
+		return a.Plies < b.Plies
+	}
+	return a.Plies > b.Plies
+}
+
+func dedupe(keys []uint64) []uint64 {
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+	out := keys[:0]
+	for i, key := range keys {
+		if i == 0 || key != keys[i-1] {
+			out = append(out, key)
+		}
+	}
+	return out
+}
+
+type byKey struct {
+	tb *Tablebase
+}
+
+func (s byKey) Len() int           { return len(s.tb.keys) }
+func (s byKey) Less(i, j int) bool { return s.tb.keys[i] < s.tb.keys[j] }
+func (s byKey) Swap(i, j int) {
+	s.tb.keys[i], s.tb.keys[j] = s.tb.keys[j], s.tb.keys[i]
+	s.tb.entries[i], s.tb.entries[j] = s.tb.entries[j], s.tb.entries[i]
+}
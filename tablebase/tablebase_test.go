@@ -0,0 +1,241 @@
+package tablebase
+
+import (
+	"FinalProject/game"
+	"bytes"
+	"math/rand"
+	"testing"
+)
+
+// Plain negamax for checking the tablebase
+func negamax(pos Position) Entry {
+	best := Entry{Result: Loss, Plies: -1}
+	for col := 0; col < pos.Width; col++ {
+		if !pos.CanPlay(col) {
+			continue
+		}
+
+		var entry Entry
+		switch next := pos.Play(col); {
+		case pos.IsWinningMove(col):
+			entry = Entry{Result: Win, Plies: 1}
+		case next.Full():
+			entry = Entry{Result: Draw, Plies: 1}
+		default:
+			entry = negamax(next)
+			entry.Plies++
+			if entry.Result == Win {
+				entry.Result = Loss
+			} else if entry.Result == Loss {
+				entry.Result = Win
+			}
+		}
+
+		if better(entry, best) {
+			best = entry
+		}
+	}
+	return best
+}
+
+func TestSmallBoard(t *testing.T) {
+	root, _ := NewPosition(4, 4)
+	tb, err := Generate(root, 1000000, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	entry, ok := tb.Lookup(root)
+	if !ok || entry.Result != Draw || entry.Plies != 16 {
+		t.Errorf("4x4 should be a draw, got %+v", entry)
+	}
+
+	// Compare random positions deep enough to solve directly
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 200; i++ {
+		pos := root
+		for pos.Moves() < 8 {
+			col := rng.Intn(4)
+			if !pos.CanPlay(col) {
+				continue
+			}
+			if pos.IsWinningMove(col) {
+				break
+			}
+			pos = pos.Play(col)
+		}
+		if pos.Over() {
+			continue
+		}
+
+		got, ok := tb.Lookup(pos)
+		if want := negamax(pos); !ok || got != want {
+			t.Fatalf("Position %x: got %+v, want %+v", pos.Key(), got, want)
+		}
+	}
+}
+
+func TestLimit(t *testing.T) {
+	root, _ := NewPosition(5, 4)
+	if _, err := Generate(root, 1000, nil); err == nil {
+		t.Error("Should stop past the limit")
+	}
+}
+
+func TestSaveLoad(t *testing.T) {
+	root, _ := ParseMoves(4, 4, "1234")
+	tb, err := Generate(root, 100000, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if err := tb.Save(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if buf.Len() > tb.Len()*4 {
+		t.Errorf("File should be compact, %d bytes for %d positions", buf.Len(), tb.Len())
+	}
+
+	loaded, err := Load(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if loaded.Len() != tb.Len() || loaded.Width != 4 || loaded.Height != 4 {
+		t.Fatalf("Loaded %d positions of %dx%d", loaded.Len(), loaded.Width, loaded.Height)
+	}
+	for i := range tb.keys {
+		if loaded.keys[i] != tb.keys[i] || loaded.entries[i] != tb.entries[i] {
+			t.Fatal("Loaded tablebase differs")
+		}
+	}
+
+	if _, err := Load(bytes.NewReader([]byte("nope"))); err == nil {
+		t.Error("Should reject a file that isn't a tablebase")
+	}
+}
+
+// A late 7x6 position with 12 empty cells where X wins in 9 plies but not
+// straight away
+func lateBoard(t *testing.T) *game.Board {
+	t.Helper()
+	moves, _ := game.ParseMoves("335232744317716722412671476234")
+	board := game.NewBoard()
+	if err := board.PlayMoves(moves); err != nil {
+		t.Fatal(err)
+	}
+	return board
+}
+
+func TestProbe(t *testing.T) {
+	board := lateBoard(t)
+	root := FromBoard(board)
+	tb, err := Generate(root, 1000000, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := negamax(root)
+	entry, ok := tb.Lookup(root)
+	if !ok || entry != want {
+		t.Fatalf("Got %+v, want %+v", entry, want)
+	}
+
+	value, ok := tb.Probe(board)
+	switch {
+	case !ok:
+		t.Error("Board should be in the tablebase")
+	case want.Result == Win && value != WinScore-want.Plies:
+		t.Errorf("Won board should probe as a win, got %d", value)
+	case want.Result == Draw && value != 0:
+		t.Errorf("Drawn board should probe as 0, got %d", value)
+	}
+
+	// Every move's result agrees with the best one
+	best := Entry{Result: Loss, Plies: -1}
+	for _, entry := range tb.Moves(root) {
+		if better(entry, best) {
+			best = entry
+		}
+	}
+	if best != want {
+		t.Errorf("Best move gives %+v, want %+v", best, want)
+	}
+}
+
+func TestProbePrefersQuickWins(t *testing.T) {
+	board := lateBoard(t)
+	tb, err := Generate(FromBoard(board), 1000000, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Won positions two plies on, by how long the win takes
+	wins := make(map[int]int)
+	for c1 := 0; c1 < game.NumCols; c1++ {
+		for c2 := 0; c2 < game.NumCols; c2++ {
+			next := board.DuplicateBoard()
+			if next.PlayMoves([]int{c1, c2}) != nil || next.CheckEndGame() {
+				continue
+			}
+			entry, ok := tb.Lookup(FromBoard(next))
+			if !ok || entry.Result != Win {
+				continue
+			}
+			value, _ := tb.Probe(next)
+			wins[entry.Plies] = value
+		}
+	}
+	if len(wins) < 2 {
+		t.Fatalf("Expected wins of different lengths, got %v", wins)
+	}
+
+	for plies, value := range wins {
+		for otherPlies, other := range wins {
+			if plies < otherPlies && value <= other {
+				t.Errorf("A win in %d plies should outrank one in %d: %d <= %d", plies, otherPlies, value, other)
+			}
+		}
+	}
+}
+
+func TestSmartPlayerUsesTablebase(t *testing.T) {
+	board := lateBoard(t)
+	tb, err := Generate(FromBoard(board), 1000000, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	player := game.NewSmartPlayer(board.WhoseTurn, 1)
+	player.Endgame = tb
+	move := player.MakeMove(board.DuplicateBoard())
+
+	moves := tb.Moves(FromBoard(board))
+	want, _ := tb.Lookup(FromBoard(board))
+	if moves[move].Result != want.Result {
+		t.Errorf("Depth 1 with a tablebase should keep the %v, but %d gives a %v", want.Result, move+1, moves[move].Result)
+	}
+}
+
+// X can win in 3, 7 or 19 plies and the search should see the difference
+// through decay
+func TestSmartPlayerTakesQuickestWin(t *testing.T) {
+	moves, _ := game.ParseMoves("33523274431771672241267")
+	board := game.NewBoard()
+	if err := board.PlayMoves(moves); err != nil {
+		t.Fatal(err)
+	}
+	tb, err := Generate(FromBoard(board), 1000000, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	player := game.NewSmartPlayer(board.WhoseTurn, 1)
+	player.Endgame = tb
+	for i := 0; i < 20; i++ {
+		if move := player.MakeMove(board.DuplicateBoard()); move != 0 {
+			t.Fatalf("Should play the win in 3 plies in column 1, played %d", move+1)
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"FinalProject/tablebase"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+)
+
+// Generates or probes an endgame tablebase, e.g.
+//
+//	tablebase -size 5x4 -out 5x4.tb
+//	tablebase -moves 335232744317716722412671476234 -out late.tb
+//	tablebase -probe late.tb -moves 3352327443177167224126714762342
+func tablebaseCommand(args []string) {
+	flags := flag.NewFlagSet("tablebase", flag.ExitOnError)
+	size := flags.String("size", "7x6", "board size as columns x rows")
+	moves := flags.String("moves", "", "columns played to reach the root, e.g. 4453")
+	out := flags.String("out", "endgame.tb", "file to write")
+	probe := flags.String("probe", "", "look up the position in this tablebase instead of generating one")
+	limit := flags.Int("limit", 50000000, "give up past this many positions")
+	flags.Parse(args)
+
+	var width, height int
+	if _, err := fmt.Sscanf(*size, "%dx%d", &width, &height); err != nil {
+		exitIf(fmt.Errorf("bad size %q", *size))
+	}
+
+	root, err := tablebase.ParseMoves(width, height, *moves)
+	exitIf(err)
+
+	if *probe != "" {
+		tb, err := loadTablebase(*probe)
+		exitIf(err)
+		probeTablebase(tb, root)
+		return
+	}
+
+	start := time.Now()
+	tb, err := tablebase.Generate(root, *limit, func(stage string, ply int, positions int) {
+		fmt.Printf("%s %d positions after %d moves\n", stage, positions, ply)
+	})
+	exitIf(err)
+
+	f, err := os.Create(*out)
+	exitIf(err)
+	if err := tb.Save(f); err != nil {
+		f.Close()
+		exitIf(err)
+	}
+	exitIf(f.Close())
+
+	entry, _ := tb.Lookup(root)
+	fmt.Printf("Root is a %v in %d plies for the side to move\n", entry.Result, entry.Plies)
+	fmt.Printf("Wrote %d positions to %s in %v\n", tb.Len(), *out, time.Since(start).Round(time.Millisecond))
+}
+
+func probeTablebase(tb *tablebase.Tablebase, pos tablebase.Position) {
+	if pos.Width != tb.Width || pos.Height != tb.Height {
+		exitIf(fmt.Errorf("tablebase is for %dx%d boards", tb.Width, tb.Height))
+	}
+
+	entry, ok := tb.Lookup(pos)
+	if !ok {
+		fmt.Println("Position is not in the tablebase")
+		return
+	}
+	fmt.Printf("%v in %d plies for the side to move\n", entry.Result, entry.Plies)
+
+	results := tb.Moves(pos)
+	for col := 0; col < pos.Width; col++ {
+		if result, ok := results[col]; ok {
+			fmt.Printf("  %d: %v in %d\n", col+1, result.Result, result.Plies)
+		}
+	}
+}
+
+func loadTablebase(path string) (*tablebase.Tablebase, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return tablebase.Load(f)
+}
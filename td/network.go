@@ -0,0 +1,204 @@
+// Package td learns an evaluation by temporal difference self-play and
+// plays with it through SmartPlayer's search.
+//
+// The evaluation is an n-tuple network: every line of four cells on the
+// board indexes a table of 81 weights, one for each way X, O and empty can
+// fill it. The board's value for X is the tanh of the summed weights, plus
+// a weight for whose turn it is. Each board is scored together with its
+// mirror image so both learn at once.
+package td
+
+import (
+	"FinalProject/game"
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+)
+
+// Every line of four cells as {col, row} pairs
+var lines = findLines()
+
+const tupleSize = 4
+
+// 3^tupleSize
+const tableSize = 81
+
+// Leaf values from Evaluate are the network output times this
+const Scale = 1000
+
+type Network struct {
+	// tableSize weights per line, then the weight for X to move
+	weights []float64
+}
+
+// Returns a network with every weight 0, which values every position as
+// even
+func NewNetwork() *Network {
+	return &Network{weights: make([]float64, len(lines)*tableSize+1)}
+}
+
+func findLines() [][tupleSize][2]int {
+	var found [][tupleSize][2]int
+	for _, d := range [4][2]int{{1, 0}, {0, 1}, {1, 1}, {1, -1}} {
+		for c := 0; c < game.NumCols; c++ {
+			for r := 0; r < game.NumRows; r++ {
+				var line [tupleSize][2]int
+				ok := true
+				for i := 0; i < tupleSize; i++ {
+					col, row := c+d[0]*i, r+d[1]*i
+					if col < 0 || col >= game.NumCols || row < 0 || row >= game.NumRows {
+						ok = false
+						break
+					}
+					line[i] = [2]int{col, row}
+				}
+				if ok {
+					found = append(found, line)
+				}
+			}
+		}
+	}
+	return found
+}
+
+// A weight used by a board and how much it counts
+type feature struct {
+	index int
+	coeff float64
+}
+
+// Returns the weights the board uses, halved since the mirror image uses
+// its own
+func (net *Network) features(board *game.Board) []feature {
+	features := make([]feature, 0, 2*len(lines)+1)
+	for mirror := 0; mirror < 2; mirror++ {
+		for i, line := range lines {
+			idx := 0
+			for _, cell := range line {
+				col := cell[0]
+				if mirror == 1 {
+					col = game.NumCols - 1 - col
+				}
+				idx *= 3
+				switch board.At(col, cell[1]) {
+				case game.Tokens[0]:
+					idx += 1
+				case game.Tokens[1]:
+					idx += 2
+				}
+			}
+			features = append(features, feature{index: i*tableSize + idx, coeff: 0.5})
+		}
+	}
+
+	turn := 1.0
+	if board.WhoseTurn == 1 {
+		turn = -1
+	}
+	return append(features, feature{index: len(net.weights) - 1, coeff: turn})
+}
+
+func (net *Network) sum(features []feature) float64 {
+	sum := 0.0
+	for _, f := range features {
+		sum += net.weights[f.index] * f.coeff
+	}
+	return sum
+}
+
+// Expected result for X from -1 (O wins) to 1 (X wins). Finished games get
+// their actual result.
+func (net *Network) Value(board *game.Board) float64 {
+	if result, over := outcome(board); over {
+		return result
+	}
+	return math.Tanh(net.sum(net.features(board)))
+}
+
+// Scores the board for SmartPlayer's search. Wins are scored like a four
+// on the board.
+func (net *Network) Evaluate(board *game.Board, token byte) int {
+	var value int
+	if result, over := outcome(board); over {
+		value = int(result) * game.ConfigValues["TTTT"]
+	} else {
+		value = int(math.Tanh(net.sum(net.features(board))) * Scale)
+	}
+
+	if token == game.Tokens[1] {
+		return -value
+	}
+	return value
+}
+
+// Returns 1 if X has won, -1 if O has, 0 for a full board, and whether the
+// game is over
+func outcome(board *game.Board) (float64, bool) {
+	if line := board.WinningLine(); line != nil {
+		if board.At(line[0][0], line[0][1]) == game.Tokens[0] {
+			return 1, true
+		}
+		return -1, true
+	}
+
+	for c := 0; c < game.NumCols; c++ {
+		if board.IsValidMove(c) {
+			return 0, false
+		}
+	}
+	return 0, true
+}
+
+// A network file is "C4TD", a version byte, the number of weights as a
+// uint32 and then each weight as a float32, all little endian
+const magic = "C4TD"
+
+const version = 1
+
+func (net *Network) Save(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	bw.WriteString(magic)
+	bw.WriteByte(version)
+	binary.Write(bw, binary.LittleEndian, uint32(len(net.weights)))
+
+	weights := make([]float32, len(net.weights))
+	for i, weight := range net.weights {
+		weights[i] = float32(weight)
+	}
+	binary.Write(bw, binary.LittleEndian, weights)
+	return bw.Flush()
+}
+
+func Load(r io.Reader) (*Network, error) {
+	header := make([]byte, len(magic)+1)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return nil, fmt.Errorf("td: reading header: %v", err)
+	}
+	if string(header[:len(magic)]) != magic {
+		return nil, errors.New("td: not a network file")
+	}
+	if header[len(magic)] != version {
+		return nil, fmt.Errorf("td: unknown version %d", header[len(magic)])
+	}
+
+	net := NewNetwork()
+	var count uint32
+	if err := binary.Read(r, binary.LittleEndian, &count); err != nil {
+		return nil, fmt.Errorf("td: reading size: %v", err)
+	}
+	if int(count) != len(net.weights) {
+		return nil, fmt.Errorf("td: file has %d weights, want %d", count, len(net.weights))
+	}
+
+	weights := make([]float32, count)
+	if err := binary.Read(r, binary.LittleEndian, weights); err != nil {
+		return nil, fmt.Errorf("td: reading weights: %v", err)
+	}
+	for i, weight := range weights {
+		net.weights[i] = float64(weight)
+	}
+	return net, nil
+}
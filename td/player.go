@@ -0,0 +1,15 @@
+package td
+
+import "FinalProject/game"
+
+// Searches like SmartPlayer with the network's evaluation at the leaves
+type TDPlayer struct {
+	*game.SmartPlayer
+	Net *Network
+}
+
+func NewTDPlayer(playerIdx int, depth int, net *Network) *TDPlayer {
+	search := game.NewSmartPlayer(playerIdx, depth)
+	search.Evaluator = net
+	return &TDPlayer{SmartPlayer: search, Net: net}
+}
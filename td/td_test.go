@@ -0,0 +1,96 @@
+package td
+
+import (
+	"FinalProject/game"
+	"bytes"
+	"math/rand"
+	"testing"
+)
+
+func TestLines(t *testing.T) {
+	if len(lines) != 69 {
+		t.Errorf("A 7x6 board has 69 lines of four, found %d", len(lines))
+	}
+}
+
+func TestMirrorValue(t *testing.T) {
+	net := NewNetwork()
+	rng := rand.New(rand.NewSource(1))
+	for i := range net.weights {
+		net.weights[i] = rng.Float64() - 0.5
+	}
+
+	board := game.NewBoard()
+	board.PlayMoves([]int{0, 1, 1, 5})
+	mirror := game.NewBoard()
+	mirror.PlayMoves([]int{6, 5, 5, 1})
+
+	if a, b := net.Value(board), net.Value(mirror); a != b {
+		t.Errorf("Mirror images should have the same value: %v %v", a, b)
+	}
+}
+
+func TestEvaluateFinished(t *testing.T) {
+	net := NewNetwork()
+	board := game.NewBoard()
+	board.PlayMoves([]int{0, 1, 0, 1, 0, 1, 0})
+
+	if net.Value(board) != 1 {
+		t.Error("X has won, so the value should be 1")
+	}
+	if net.Evaluate(board, 'O') != -game.ConfigValues["TTTT"] {
+		t.Error("A loss should be scored like a four on the board")
+	}
+}
+
+func TestSaveLoad(t *testing.T) {
+	net := NewNetwork()
+	net.weights[10] = 0.25
+	net.weights[len(net.weights)-1] = -1.5
+
+	var buf bytes.Buffer
+	if err := net.Save(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := Load(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loaded.weights[10] != 0.25 || loaded.weights[len(loaded.weights)-1] != -1.5 {
+		t.Error("Weights should survive a save and load")
+	}
+
+	if _, err := Load(bytes.NewReader([]byte("C4TD\x01\x05\x00\x00\x00"))); err == nil {
+		t.Error("Should reject a file with the wrong number of weights")
+	}
+}
+
+func TestTrainLearnsWins(t *testing.T) {
+	trainer := NewTrainer(NewNetwork())
+	trainer.Rand = rand.New(rand.NewSource(1))
+	for i := 0; i < 200; i++ {
+		trainer.PlayGame()
+	}
+
+	// X has three stacked with O nowhere near, and X to move
+	board := game.NewBoard()
+	board.PlayMoves([]int{0, 6, 0, 6, 0, 5})
+
+	if value := trainer.Net.Value(board); value <= 0 {
+		t.Errorf("Three in a column with X to move should look good for X, got %v", value)
+	}
+}
+
+func TestTDPlayer(t *testing.T) {
+	board := game.NewBoard()
+	board.PlayMoves([]int{0, 1, 0, 1, 0, 1})
+
+	player := NewTDPlayer(0, 2, NewNetwork())
+	if move := player.MakeMove(board); move != 0 {
+		t.Errorf("Should take the win, played %d", move)
+	}
+	if player.Last.Move != 0 {
+		t.Error("Analysis should be kept like SmartPlayer's")
+	}
+}
@@ -0,0 +1,102 @@
+package td
+
+import (
+	"FinalProject/game"
+	"math/rand"
+	"time"
+)
+
+// Trains a network by TD(λ) self-play. Both sides pick the move whose
+// position the network likes best for them, or a random move with
+// probability Epsilon, and after each move the value of the position before
+// is pulled towards the value of the position after.
+type Trainer struct {
+	Net *Network
+
+	// Learning rate
+	Alpha float64
+
+	// How far back each error reaches, from 0 (just the last position) to 1
+	// (the whole game)
+	Lambda float64
+
+	// Chance of exploring with a random move
+	Epsilon float64
+
+	Rand *rand.Rand
+}
+
+func NewTrainer(net *Network) *Trainer {
+	return &Trainer{
+		Net:     net,
+		Alpha:   0.005,
+		Lambda:  0.7,
+		Epsilon: 0.1,
+		Rand:    rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+// Plays one game against itself, learning as it goes. Returns the winning
+// token, or ' ' for a tie.
+func (trainer *Trainer) PlayGame() byte {
+	board := game.NewBoard()
+	traces := make(map[int]float64)
+
+	for {
+		features := trainer.Net.features(board)
+		value := trainer.Net.Value(board)
+
+		board.MakeMove(trainer.chooseMove(board))
+
+		// The error is measured against the game's result once it's over
+		target := trainer.Net.Value(board)
+		over := board.CheckEndGame()
+
+		// d tanh(s) / ds
+		grad := 1 - value*value
+		for key, trace := range traces {
+			traces[key] = trace * trainer.Lambda
+		}
+		for _, f := range features {
+			traces[f.index] += grad * f.coeff
+		}
+
+		delta := target - value
+		for index, trace := range traces {
+			trainer.Net.weights[index] += trainer.Alpha * delta * trace
+		}
+
+		if over {
+			return board.Winner
+		}
+	}
+}
+
+func (trainer *Trainer) chooseMove(board *game.Board) int {
+	var valid []int
+	for col := 0; col < game.NumCols; col++ {
+		if board.IsValidMove(col) {
+			valid = append(valid, col)
+		}
+	}
+
+	if trainer.Rand.Float64() < trainer.Epsilon {
+		return valid[trainer.Rand.Intn(len(valid))]
+	}
+
+	// X wants the highest value and O the lowest
+	sign := 1.0
+	if board.WhoseTurn == 1 {
+		sign = -1
+	}
+
+	best, bestValue := valid[0], -2.0
+	for _, col := range valid {
+		next := board.DuplicateBoard()
+		next.MakeMove(col)
+		if value := sign * trainer.Net.Value(next); value > bestValue {
+			best, bestValue = col, value
+		}
+	}
+	return best
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"FinalProject/game"
+	"FinalProject/td"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+)
+
+// Trains a TD network by self-play, then pits it against the handcrafted
+// evaluation at the same search depth
+func tdCommand(args []string) {
+	flags := flag.NewFlagSet("td", flag.ExitOnError)
+	games := flags.Int("games", 10000, "self-play games to train on")
+	alpha := flags.Float64("alpha", 0.005, "learning rate")
+	lambda := flags.Float64("lambda", 0.7, "trace decay")
+	epsilon := flags.Float64("epsilon", 0.1, "chance of a random move while training")
+	start := flags.String("start", "", "network file to keep training (default a new network)")
+	out := flags.String("out", "td.weights", "file to write")
+	report := flags.Int("report", 1000, "print results every this many games")
+	compare := flags.Int("compare", 20, "games against smart players after training (0 to skip)")
+	depth := flags.Int("depth", 3, "search depth for the comparison")
+	flags.Parse(args)
+
+	net := td.NewNetwork()
+	if *start != "" {
+		var err error
+		net, err = loadNetwork(*start)
+		exitIf(err)
+	}
+
+	trainer := td.NewTrainer(net)
+	trainer.Alpha = *alpha
+	trainer.Lambda = *lambda
+	trainer.Epsilon = *epsilon
+
+	began := time.Now()
+	var wins [3]int
+	for i := 1; i <= *games; i++ {
+		switch trainer.PlayGame() {
+		case game.Tokens[0]:
+			wins[0]++
+		case game.Tokens[1]:
+			wins[1]++
+		default:
+			wins[2]++
+		}
+
+		if *report > 0 && i%*report == 0 {
+			fmt.Printf("%d games: X won %d, O won %d, %d ties\n", i, wins[0], wins[1], wins[2])
+			wins = [3]int{}
+		}
+	}
+	fmt.Printf("Trained in %v\n", time.Since(began).Round(time.Second))
+
+	f, err := os.Create(*out)
+	exitIf(err)
+	if err := net.Save(f); err != nil {
+		f.Close()
+		exitIf(err)
+	}
+	exitIf(f.Close())
+	fmt.Printf("Wrote %s\n", *out)
+
+	if *compare > 0 {
+		compareTD(net, *depth, *compare)
+	}
+}
+
+// Plays the learned evaluation against the handcrafted one, switching who
+// goes first
+func compareTD(net *td.Network, depth int, games int) {
+	var wins [3]int
+	for i := 0; i < games; i++ {
+		tdIdx := i % 2
+		var players [2]game.Player
+		players[tdIdx] = td.NewTDPlayer(tdIdx, depth, net)
+		players[1-tdIdx] = game.NewSmartPlayer(1-tdIdx, depth)
+
+		match := game.NewMatch(players[0], players[1])
+		match.Board.WhoseTurn = (i / 2) % 2
+
+		switch match.Play() {
+		case game.Tokens[tdIdx]:
+			wins[0]++
+		case ' ':
+			wins[2]++
+		default:
+			wins[1]++
+		}
+	}
+	fmt.Printf("At depth %d the TD player won %d, the smart player won %d, %d ties\n", depth, wins[0], wins[1], wins[2])
+}
+
+func loadNetwork(path string) (*td.Network, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return td.Load(f)
+}
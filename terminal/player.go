@@ -0,0 +1,125 @@
+package terminal
+
+import (
+	"FinalProject/game"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+const (
+	keyCtrlC = 3
+	keyEsc   = 27
+)
+
+// A human player that picks a column by moving a cursor with the arrow keys
+// and dropping with enter or space. Number keys 1-7 drop straight into that
+// column. If the input can't be put into raw mode the player asks for a
+// column number on a line instead.
+type HumanPlayer struct {
+	Renderer *Renderer
+	In       *os.File
+	Prompt   string
+
+	cursor int
+}
+
+func NewHumanPlayer(renderer *Renderer, in *os.File) *HumanPlayer {
+	return &HumanPlayer{Renderer: renderer, In: in, cursor: game.NumCols / 2}
+}
+
+func (player *HumanPlayer) MakeMove(board *game.Board) int {
+	// Raw mode is only worth it when the board is drawn with a cursor
+	move := -1
+	if player.Renderer.ANSI() {
+		if restore, err := makeRaw(player.In); err == nil {
+			move = player.readKeys(board, restore)
+			restore()
+		}
+	}
+	if move < 0 {
+		move = player.readLine(board)
+	}
+
+	board.MakeMove(move)
+	return move
+}
+
+func (player *HumanPlayer) readKeys(board *game.Board, restore func()) int {
+	status := player.Prompt
+	if status == "" {
+		status = "Your move: ←/→ to choose, enter to drop, q to quit"
+	}
+
+	player.cursor = nearestValid(board, player.cursor, 1)
+	buf := make([]byte, 8)
+	for {
+		player.Renderer.Draw(board, player.cursor, status)
+
+		n, err := player.In.Read(buf)
+		if err != nil || n == 0 {
+			player.quit(restore)
+		}
+
+		switch key := buf[:n]; {
+		case n >= 3 && key[0] == keyEsc && key[1] == '[' && key[2] == 'C',
+			key[0] == 'l', key[0] == 'd':
+			player.cursor = nearestValid(board, (player.cursor+1)%game.NumCols, 1)
+		case n >= 3 && key[0] == keyEsc && key[1] == '[' && key[2] == 'D',
+			key[0] == 'h', key[0] == 'a':
+			player.cursor = nearestValid(board, (player.cursor+game.NumCols-1)%game.NumCols, -1)
+		case key[0] == '\r', key[0] == '\n', key[0] == ' ':
+			return player.cursor
+		case key[0] >= '1' && key[0] < '1'+game.NumCols:
+			col := int(key[0] - '1')
+			if board.IsValidMove(col) {
+				player.cursor = col
+				return col
+			}
+		case key[0] == 'q', key[0] == keyCtrlC:
+			player.quit(restore)
+		}
+	}
+}
+
+func (player *HumanPlayer) readLine(board *game.Board) int {
+	// Players at the same terminal share its reader, so none of them
+	// buffers lines meant for another
+	reader := player.Renderer.lineReader(player.In)
+	for {
+		player.Renderer.Draw(board, -1, "")
+		fmt.Fprint(player.Renderer.out, "Enter column (1-7): ")
+		text, err := reader.ReadString('\n')
+		if err != nil && text == "" {
+			player.quit(nil)
+		}
+
+		move, _ := strconv.Atoi(strings.TrimSpace(text))
+		if move >= 1 && move <= game.NumCols && board.IsValidMove(move-1) {
+			return move - 1
+		}
+	}
+}
+
+// Puts the terminal back and leaves. There is no way to abandon a move
+// through the Player interface, so quitting ends the program.
+func (player *HumanPlayer) quit(restore func()) {
+	if restore != nil {
+		restore()
+	}
+	player.Renderer.Close()
+	fmt.Fprintln(player.Renderer.out)
+	os.Exit(1)
+}
+
+// Returns the first valid column starting at col and stepping by dir
+func nearestValid(board *game.Board, col int, dir int) int {
+	for i := 0; i < game.NumCols; i++ {
+		c := ((col+dir*i)%game.NumCols + game.NumCols) % game.NumCols
+		if board.IsValidMove(c) {
+			return c
+		}
+	}
+	return col
+}
@@ -0,0 +1,29 @@
+package terminal
+
+import (
+	"FinalProject/game"
+	"bytes"
+	"os"
+	"testing"
+)
+
+// Hot-seat players reading lines from one pipe each get their own line
+func TestHumanPlayersShareInput(t *testing.T) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	w.WriteString("4\n5\n")
+	w.Close()
+
+	renderer := NewPlainRenderer(&bytes.Buffer{})
+	players := []*HumanPlayer{NewHumanPlayer(renderer, r), NewHumanPlayer(renderer, r)}
+
+	board := game.NewBoard()
+	for i, want := range []int{3, 4} {
+		if move := players[i].MakeMove(board); move != want {
+			t.Errorf("Player %d should read column %d, got %d", i+1, want+1, move+1)
+		}
+	}
+}
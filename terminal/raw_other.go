@@ -0,0 +1,14 @@
+//go:build !linux
+
+package terminal
+
+import (
+	"errors"
+	"os"
+)
+
+// Raw mode is only implemented for Linux. Everywhere else the human player
+// falls back to reading whole lines.
+func makeRaw(f *os.File) (func(), error) {
+	return nil, errors.New("terminal: raw mode not supported on this platform")
+}
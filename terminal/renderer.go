@@ -0,0 +1,182 @@
+// Package terminal draws boards with ANSI escape codes and reads column
+// choices from the keyboard. When the output is not a terminal it falls
+// back to the plain text board from game.Board.Print.
+package terminal
+
+import (
+	"FinalProject/game"
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+const (
+	clearScreen = "\x1b[H\x1b[2J"
+	hideCursor  = "\x1b[?25l"
+	showCursor  = "\x1b[?25h"
+	reset       = "\x1b[0m"
+	bold        = "\x1b[1m"
+	blue        = "\x1b[34m"
+	dim         = "\x1b[2m"
+)
+
+// Colors used for each entry of game.Tokens
+var TokenColors = [2]string{"\x1b[31m", "\x1b[33m"}
+
+// Time each frame of the falling piece stays on screen
+var DropDelay = 40 * time.Millisecond
+
+type Renderer struct {
+	out  io.Writer
+	ansi bool
+
+	// Buffered input shared by the human players drawing here
+	lines     *bufio.Reader
+	linesFrom io.Reader
+}
+
+// Returns a renderer writing to out. Escape codes are only used if out is a
+// terminal.
+func NewRenderer(out *os.File) *Renderer {
+	return &Renderer{out: out, ansi: IsTerminal(out)}
+}
+
+// Returns a renderer that never writes escape codes
+func NewPlainRenderer(out io.Writer) *Renderer {
+	return &Renderer{out: out}
+}
+
+// Whether the renderer is drawing with escape codes
+func (r *Renderer) ANSI() bool {
+	return r.ansi
+}
+
+// Returns the reader for lines typed into in, the same one each time
+func (r *Renderer) lineReader(in io.Reader) *bufio.Reader {
+	if r.lines == nil || r.linesFrom != in {
+		r.lines = bufio.NewReader(in)
+		r.linesFrom = in
+	}
+	return r.lines
+}
+
+// Returns whether f refers to a terminal (character device)
+func IsTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// Draws the board with an arrow above the cursor column and the status line
+// beneath it. A cursor outside the board hides the arrow.
+func (r *Renderer) Draw(board *game.Board, cursor int, status string) {
+	if !r.ansi {
+		r.drawPlain(board, status)
+		return
+	}
+	r.drawFrame(board, cursor, status, -1, -1, 0)
+}
+
+// Animates the token in the top of col falling into place. The board passed
+// in must already contain the move.
+func (r *Renderer) AnimateDrop(board *game.Board, col int, status string) {
+	if !r.ansi {
+		r.drawPlain(board, status)
+		return
+	}
+
+	row := board.Height(col) - 1
+	if row < 0 {
+		r.Draw(board, -1, status)
+		return
+	}
+
+	token := board.At(col, row)
+	for fall := game.NumRows - 1; fall > row; fall-- {
+		r.drawFrame(board, -1, status, col, fall, token)
+		time.Sleep(DropDelay)
+	}
+	r.Draw(board, -1, status)
+}
+
+// Leaves the terminal the way we found it
+func (r *Renderer) Close() {
+	if r.ansi {
+		fmt.Fprint(r.out, showCursor)
+	}
+}
+
+func (r *Renderer) drawPlain(board *game.Board, status string) {
+	board.Fprint(r.out)
+	if status != "" {
+		fmt.Fprintln(r.out, status)
+	}
+}
+
+// Draws one full frame. If fallCol is on the board, the cell at
+// (fallCol, fallRow) shows fallToken and the real top of that column is
+// hidden so the piece appears to be moving.
+func (r *Renderer) drawFrame(board *game.Board, cursor int, status string, fallCol, fallRow int, fallToken byte) {
+	var sb strings.Builder
+	sb.WriteString(hideCursor + clearScreen)
+
+	// Cursor line
+	sb.WriteString(" ")
+	for col := 0; col < game.NumCols; col++ {
+		if col == cursor {
+			sb.WriteString(" " + bold + colorFor(board.WhoseTurn) + "▼" + reset + "  ")
+		} else {
+			sb.WriteString("    ")
+		}
+	}
+	sb.WriteString("\r\n")
+
+	landing := -1
+	if fallCol >= 0 && fallCol < game.NumCols {
+		landing = board.Height(fallCol) - 1
+	}
+
+	for row := game.NumRows - 1; row >= 0; row-- {
+		sb.WriteString(blue + "│" + reset)
+		for col := 0; col < game.NumCols; col++ {
+			token := board.At(col, row)
+			if col == fallCol {
+				if row == fallRow {
+					token = fallToken
+				} else if row == landing {
+					token = ' '
+				}
+			}
+			sb.WriteString(" " + cell(token) + " " + blue + "│" + reset)
+		}
+		sb.WriteString("\r\n")
+	}
+	sb.WriteString(blue + "└" + strings.Repeat("───┴", game.NumCols-1) + "───┘" + reset + "\r\n")
+
+	sb.WriteString(dim)
+	for col := 0; col < game.NumCols; col++ {
+		fmt.Fprintf(&sb, "  %d ", col+1)
+	}
+	sb.WriteString(reset + "\r\n\r\n")
+
+	sb.WriteString(status + "\r\n")
+	fmt.Fprint(r.out, sb.String())
+}
+
+func cell(token byte) string {
+	for i, val := range game.Tokens {
+		if token == val {
+			return colorFor(i) + "●" + reset
+		}
+	}
+	return " "
+}
+
+func colorFor(playerIdx int) string {
+	return TokenColors[playerIdx%len(TokenColors)]
+}
@@ -0,0 +1,81 @@
+package terminal
+
+import (
+	"FinalProject/game"
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPlainRendererHasNoEscapes(t *testing.T) {
+	var buf bytes.Buffer
+	r := NewPlainRenderer(&buf)
+
+	board := game.NewBoard()
+	board.MakeMove(3)
+	r.AnimateDrop(board, 3, "status line")
+
+	out := buf.String()
+	if strings.Contains(out, "\x1b") {
+		t.Error("Plain renderer should not write escape codes")
+	}
+
+	if !strings.Contains(out, "| X |") || !strings.HasSuffix(out, "status line\n") {
+		t.Errorf("Plain renderer should print the board and status:\n%s", out)
+	}
+}
+
+func TestANSIRendererColorsTokens(t *testing.T) {
+	var buf bytes.Buffer
+	r := &Renderer{out: &buf, ansi: true}
+
+	board := game.NewBoard()
+	board.MakeMove(0)
+	board.MakeMove(1)
+	r.Draw(board, 2, "X to move")
+
+	out := buf.String()
+	if !strings.HasPrefix(out, hideCursor+clearScreen) {
+		t.Error("Each frame should start by clearing the screen")
+	}
+
+	if !strings.Contains(out, TokenColors[0]+"●") || !strings.Contains(out, TokenColors[1]+"●") {
+		t.Error("Both tokens should be drawn in their colors")
+	}
+
+	if !strings.Contains(out, "▼") || !strings.Contains(out, "X to move") {
+		t.Error("Frame should contain the cursor and the status line")
+	}
+}
+
+func TestAnimateDropFrames(t *testing.T) {
+	var buf bytes.Buffer
+	r := &Renderer{out: &buf, ansi: true}
+	defer func(d time.Duration) { DropDelay = d }(DropDelay)
+	DropDelay = 0
+
+	board := game.NewBoard()
+	board.MakeMove(0)
+	r.AnimateDrop(board, 0, "")
+
+	// One frame for each row the piece falls through plus the final board
+	if frames := strings.Count(buf.String(), clearScreen); frames != game.NumRows {
+		t.Errorf("Expected %d frames, got %d", game.NumRows, frames)
+	}
+}
+
+func TestNearestValid(t *testing.T) {
+	board := game.NewBoard()
+	for i := 0; i < game.NumRows; i++ {
+		board.MakeMove(3)
+	}
+
+	if col := nearestValid(board, 3, 1); col != 4 {
+		t.Errorf("Moving right from a full column should land on 4, not %d", col)
+	}
+
+	if col := nearestValid(board, 3, -1); col != 2 {
+		t.Errorf("Moving left from a full column should land on 2, not %d", col)
+	}
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"FinalProject/game"
+	"FinalProject/tune"
+	"bufio"
+	"flag"
+	"fmt"
+	"os"
+)
+
+// Fits the pattern weights to positions with known results, e.g.
+//
+//	texel -out weights.txt solved.txt game1.txt game2.txt
+//
+// Each file is either saved games or lines of labeled positions as read by
+// tune.LoadSamples.
+func texelCommand(args []string) {
+	flags := flag.NewFlagSet("texel", flag.ExitOnError)
+	skip := flags.Int("skip", 4, "leave out this many opening moves of saved games")
+	iterations := flags.Int("iterations", 200, "fitting iterations")
+	start := flags.String("start", "", "weights file to start from (default the built in weights)")
+	out := flags.String("out", "weights.txt", "file to write")
+	flags.Usage = func() {
+		fmt.Fprintln(os.Stderr, "usage: texel [flags] file...")
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+
+	if flags.NArg() == 0 {
+		flags.Usage()
+		os.Exit(2)
+	}
+
+	var samples []tune.Sample
+	for _, path := range flags.Args() {
+		loaded, err := loadSamples(path, *skip)
+		exitIf(err)
+		samples = append(samples, loaded...)
+	}
+	fmt.Printf("Loaded %d positions\n", len(samples))
+
+	initial := game.DefaultWeights()
+	if *start != "" {
+		var err error
+		initial, err = loadWeights(*start)
+		exitIf(err)
+	}
+
+	texel := tune.NewTexel()
+	texel.Iterations = *iterations
+	fit := texel.Fit(initial, samples)
+
+	fmt.Printf("Scale K = %.5f\n", fit.K)
+	fmt.Printf("Before: log loss %.4f, accuracy %.1f%%\n", fit.Before.Loss, 100*fit.Before.Accuracy)
+	fmt.Printf("After:  log loss %.4f, accuracy %.1f%%\n", fit.After.Loss, 100*fit.After.Accuracy)
+	for _, pattern := range game.WeightPatterns() {
+		fmt.Printf("  %-8q %3d -> %d\n", pattern, initial.Config[pattern], fit.Weights.Config[pattern])
+	}
+
+	f, err := os.Create(*out)
+	exitIf(err)
+	if err := game.SaveWeights(f, fit.Weights); err != nil {
+		f.Close()
+		exitIf(err)
+	}
+	exitIf(f.Close())
+	fmt.Printf("Wrote %s\n", *out)
+}
+
+// Saved games start with a header, labeled positions don't
+func loadSamples(path string, skip int) ([]tune.Sample, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	br := bufio.NewReader(f)
+	if first, err := br.Peek(1); err == nil && first[0] == '[' {
+		record, err := game.LoadGame(br)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", path, err)
+		}
+		return tune.SamplesFromRecord(record, skip), nil
+	}
+
+	samples, err := tune.LoadSamples(br)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+	return samples, nil
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"FinalProject/game"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+)
+
+// Exports the tree SmartPlayer searches for a position, e.g.
+//
+//	tree -moves 4453 -depth 3 -show 2 | dot -Tsvg > tree.svg
+func treeCommand(args []string) {
+	flags := flag.NewFlagSet("tree", flag.ExitOnError)
+	moves := flags.String("moves", "", "columns played, e.g. 4453")
+	first := flags.String("first", "x", "token that moved first (x or o)")
+	depth := flags.Int("depth", 3, "layers to search")
+	show := flags.Int("show", 2, "layers to export (0 for all searched)")
+	format := flags.String("format", "dot", "dot or json")
+	out := flags.String("out", "-", "file to write, or - for stdout")
+	flags.Parse(args)
+
+	if *format != "dot" && *format != "json" {
+		exitIf(fmt.Errorf("unknown format %q", *format))
+	}
+
+	firstIdx, err := tokenIndex(*first)
+	exitIf(err)
+	cols, err := game.ParseMoves(*moves)
+	exitIf(err)
+
+	board := game.NewBoard()
+	board.WhoseTurn = firstIdx
+	exitIf(board.PlayMoves(cols))
+
+	root := game.SearchTree(board, *depth)
+	if *show > 0 {
+		root = root.Prune(*show)
+	}
+
+	write := func(w io.Writer) error {
+		if *format == "json" {
+			enc := json.NewEncoder(w)
+			enc.SetIndent("", "  ")
+			return enc.Encode(root)
+		}
+		return game.WriteDOT(w, root)
+	}
+
+	if *out == "-" {
+		exitIf(write(os.Stdout))
+		return
+	}
+
+	f, err := os.Create(*out)
+	exitIf(err)
+	if err := write(f); err != nil {
+		f.Close()
+		exitIf(err)
+	}
+	exitIf(f.Close())
+	fmt.Printf("Wrote %d nodes to %s\n", root.Size(), *out)
+}
@@ -0,0 +1,85 @@
+// Package tune fits SmartPlayer's evaluation weights, by self-play or from
+// positions with known outcomes.
+package tune
+
+import (
+	"FinalProject/game"
+	"math/rand"
+	"sync"
+)
+
+// Plays pairs of games between players using weights a and b, each pair
+// from the same random opening with each side going first once. Returns
+// a's score from -1 (lost every game) to 1 (won every game).
+func Compare(a *game.Weights, b *game.Weights, depth int, pairs int, openingPlies int, rng *rand.Rand) float64 {
+	if pairs < 1 {
+		return 0
+	}
+
+	// Openings are picked up front so the games can run at once
+	openings := make([][]int, pairs)
+	for i := range openings {
+		openings[i] = randomOpening(openingPlies, rng)
+	}
+
+	wg := &sync.WaitGroup{}
+	results := make([]int, 2*pairs)
+	for i := range results {
+		wg.Add(1)
+		go func(idx int) {
+			defer wg.Done()
+			results[idx] = playGame(a, b, depth, openings[idx/2], idx%2)
+		}(i)
+	}
+	wg.Wait()
+
+	total := 0
+	for _, result := range results {
+		total += result
+	}
+	return float64(total) / float64(len(results))
+}
+
+// Plays one game with a's player at index aIdx. Returns 1 if a wins, -1 if
+// b wins and 0 for a tie.
+func playGame(a *game.Weights, b *game.Weights, depth int, opening []int, aIdx int) int {
+	var players [2]game.Player
+	for i, weights := range [2]*game.Weights{a, b} {
+		idx := (aIdx + i) % 2
+		player := game.NewSmartPlayer(idx, depth)
+		player.Weights = weights
+		players[idx] = player
+	}
+
+	match := game.NewMatch(players[0], players[1])
+	match.Board.PlayMoves(opening)
+
+	switch match.Play() {
+	case game.Tokens[aIdx]:
+		return 1
+	case ' ':
+		return 0
+	}
+	return -1
+}
+
+// Random moves that don't end the game
+func randomOpening(plies int, rng *rand.Rand) []int {
+	board := game.NewBoard()
+	var moves []int
+	for len(moves) < plies {
+		move := rng.Intn(game.NumCols)
+		if !board.IsValidMove(move) {
+			continue
+		}
+
+		next := board.DuplicateBoard()
+		next.MakeMove(move)
+		if next.CheckEndGame() {
+			continue
+		}
+		board = next
+		moves = append(moves, move)
+	}
+	return moves
+}
@@ -0,0 +1,136 @@
+package tune
+
+import (
+	"FinalProject/game"
+	"math"
+	"math/rand"
+	"time"
+)
+
+// One tuned value: the weight shared by some patterns, or Decay if there
+// are no patterns
+type Param struct {
+	Name     string
+	Patterns []string
+
+	Min float64
+	Max float64
+
+	// Typical size of a change worth testing
+	Step float64
+}
+
+// Tunes each pattern weight, with mirror image patterns such as "TT " and
+// " TT" kept equal, and Decay
+func DefaultParams() []Param {
+	return []Param{
+		{Name: "T", Patterns: []string{"T ", " T"}, Max: 50, Step: 1},
+		{Name: " T ", Patterns: []string{" T "}, Max: 50, Step: 1},
+		{Name: "TT", Patterns: []string{"TT ", " TT"}, Max: 50, Step: 1},
+		{Name: " TT ", Patterns: []string{" TT "}, Max: 50, Step: 2},
+		{Name: "TTT", Patterns: []string{"TTT ", " TTT"}, Max: 50, Step: 2},
+		{Name: " TTT ", Patterns: []string{" TTT "}, Max: 100, Step: 3},
+		{Name: "decay", Min: 0.5, Max: 1, Step: 0.02},
+	}
+}
+
+// Reads the params out of weights, in units of each param's Step
+func toVector(weights *game.Weights, params []Param) []float64 {
+	theta := make([]float64, len(params))
+	for i, param := range params {
+		if len(param.Patterns) == 0 {
+			theta[i] = weights.Decay / param.Step
+		} else {
+			theta[i] = float64(weights.Config[param.Patterns[0]]) / param.Step
+		}
+	}
+	return theta
+}
+
+// Returns base with the params set from theta, kept within bounds
+func fromVector(base *game.Weights, params []Param, theta []float64) *game.Weights {
+	weights := base.Copy()
+	for i, param := range params {
+		value := math.Max(param.Min, math.Min(param.Max, theta[i]*param.Step))
+		if len(param.Patterns) == 0 {
+			weights.Decay = value
+			continue
+		}
+		for _, pattern := range param.Patterns {
+			weights.Config[pattern] = int(math.Round(value))
+		}
+	}
+	return weights
+}
+
+// Simultaneous perturbation stochastic approximation. Each iteration nudges
+// every param up or down at random, plays the two resulting weight sets
+// against each other and moves towards the winner.
+type SPSA struct {
+	Params     []Param
+	Iterations int
+
+	// Game pairs per iteration, searched to Depth from random openings of
+	// OpeningPlies moves
+	Pairs        int
+	Depth        int
+	OpeningPlies int
+
+	// Largest move per iteration, in Steps, for a clean sweep
+	LearningRate float64
+
+	Rand *rand.Rand
+
+	// Called after each iteration with the weights so far and the score of
+	// the nudged up set against the nudged down one
+	OnIteration func(iteration int, weights *game.Weights, score float64)
+}
+
+func NewSPSA() *SPSA {
+	return &SPSA{
+		Params:       DefaultParams(),
+		Iterations:   100,
+		Pairs:        8,
+		Depth:        2,
+		OpeningPlies: 2,
+		LearningRate: 2,
+		Rand:         rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+func (spsa *SPSA) Tune(start *game.Weights) *game.Weights {
+	theta := toVector(start, spsa.Params)
+	weights := fromVector(start, spsa.Params, theta)
+
+	for k := 0; k < spsa.Iterations; k++ {
+		// Usual SPSA gain schedules: big changes early, smaller later
+		a := spsa.LearningRate / math.Pow(float64(k+1), 0.602)
+		c := 1 / math.Pow(float64(k+1), 0.101)
+
+		delta := make([]float64, len(theta))
+		plus := make([]float64, len(theta))
+		minus := make([]float64, len(theta))
+		for i := range theta {
+			delta[i] = float64(2*spsa.Rand.Intn(2) - 1)
+			plus[i] = theta[i] + c*delta[i]
+			minus[i] = theta[i] - c*delta[i]
+		}
+
+		score := Compare(fromVector(start, spsa.Params, plus), fromVector(start, spsa.Params, minus), spsa.Depth, spsa.Pairs, spsa.OpeningPlies, spsa.Rand)
+
+		// The gradient estimate is score / (2 c delta), with the 2 c taken
+		// into the learning rate. delta is ±1, so dividing is multiplying.
+		for i := range theta {
+			theta[i] += a * score * delta[i]
+
+			// Keep theta inside the bounds so it can come back
+			theta[i] = math.Max(spsa.Params[i].Min/spsa.Params[i].Step, math.Min(spsa.Params[i].Max/spsa.Params[i].Step, theta[i]))
+		}
+
+		weights = fromVector(start, spsa.Params, theta)
+		if spsa.OnIteration != nil {
+			spsa.OnIteration(k+1, weights, score)
+		}
+	}
+	return weights
+}
@@ -0,0 +1,269 @@
+package tune
+
+import (
+	"FinalProject/game"
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+)
+
+// A position and how the game from it ended: 1 if X won, 0 if O won, 0.5
+// for a draw
+type Sample struct {
+	Board  *game.Board
+	Result float64
+}
+
+// Reads samples written one per line as the position from
+// game.Board.Encode followed by the result, X, O or draw:
+//
+//	......./......./......./......./......./...X... O X
+//
+// This is easy to produce from a solver's output.
+func LoadSamples(r io.Reader) ([]Sample, error) {
+	var samples []Sample
+	scanner := bufio.NewScanner(r)
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) != 3 {
+			return nil, fmt.Errorf("tune: line %d: want a position and a result", lineNum)
+		}
+
+		board, err := game.DecodeBoard(fields[0] + " " + fields[1])
+		if err != nil {
+			return nil, fmt.Errorf("tune: line %d: %v", lineNum, err)
+		}
+		result, ok := resultValue(fields[2])
+		if !ok {
+			return nil, fmt.Errorf("tune: line %d: bad result %q", lineNum, fields[2])
+		}
+		samples = append(samples, Sample{Board: board, Result: result})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return samples, nil
+}
+
+// Labels each position of a finished game after the first skip moves with
+// the game's result. Returns nothing for unfinished games.
+func SamplesFromRecord(record *game.Record, skip int) []Sample {
+	result, ok := resultValue(record.Result)
+	if !ok {
+		return nil
+	}
+
+	var samples []Sample
+	for n := skip; n < len(record.Moves); n++ {
+		board, err := record.BoardAt(n)
+		if err != nil {
+			break
+		}
+		samples = append(samples, Sample{Board: board, Result: result})
+	}
+	return samples
+}
+
+func resultValue(result string) (float64, bool) {
+	switch result {
+	case string(game.Tokens[0]):
+		return 1, true
+	case string(game.Tokens[1]):
+		return 0, true
+	case game.ResultDraw:
+		return 0.5, true
+	}
+	return 0, false
+}
+
+// Texel tuning: fits pattern weights so that a logistic curve of
+// CalcPlayerValue predicts the results of the samples
+type Texel struct {
+	// Params with no patterns, such as decay, are left alone
+	Params     []Param
+	Iterations int
+
+	// Pulls the weights back towards the start so that a small or easily
+	// separated set of samples doesn't send them to the bounds
+	Regularization float64
+
+	// Called after each iteration with the log loss so far
+	OnIteration func(iteration int, loss float64)
+}
+
+func NewTexel() *Texel {
+	return &Texel{Params: DefaultParams(), Iterations: 200, Regularization: 1e-4}
+}
+
+// How well weights predict a set of samples
+type Stats struct {
+	// Mean log loss of the predicted chance X wins
+	Loss float64
+
+	// Share of decided games where the evaluation favoured the winner
+	Accuracy float64
+}
+
+type Fit struct {
+	Weights *game.Weights
+
+	// Scale from evaluation to logit: P(X wins) = 1 / (1 + e^(-K eval))
+	K float64
+
+	Before Stats
+	After  Stats
+}
+
+// Fits the weights of start to samples. Finished positions are skipped.
+func (texel *Texel) Fit(start *game.Weights, samples []Sample) *Fit {
+	var params []Param
+	for _, param := range texel.Params {
+		if len(param.Patterns) > 0 {
+			params = append(params, param)
+		}
+	}
+
+	var data []Sample
+	var features [][]float64
+	for _, sample := range samples {
+		if !sample.Board.CheckEndGame() {
+			data = append(data, sample)
+			features = append(features, patternFeatures(sample.Board, params))
+		}
+	}
+
+	if len(data) == 0 {
+		return &Fit{Weights: start.Copy(), K: 1}
+	}
+
+	w := make([]float64, len(params))
+	for i, param := range params {
+		w[i] = float64(start.Config[param.Patterns[0]])
+	}
+	w0 := append([]float64(nil), w...)
+
+	fit := &Fit{K: fitK(features, data, w)}
+	fit.Before = stats(features, data, w, fit.K)
+
+	for iteration := 1; iteration <= texel.Iterations; iteration++ {
+		// Newton steps on each weight using the diagonal of the Hessian
+		grad := make([]float64, len(w))
+		hess := make([]float64, len(w))
+		for j, f := range features {
+			p := sigmoid(fit.K * dot(w, f))
+			for i := range w {
+				grad[i] += (p - data[j].Result) * fit.K * f[i]
+				hess[i] += p * (1 - p) * fit.K * fit.K * f[i] * f[i]
+			}
+		}
+		for i, param := range params {
+			grad[i] = grad[i]/float64(len(data)) + 2*texel.Regularization*(w[i]-w0[i])
+			hess[i] = hess[i]/float64(len(data)) + 2*texel.Regularization
+			if hess[i] > 0 {
+				w[i] -= 0.5 * grad[i] / hess[i]
+			}
+			w[i] = math.Max(param.Min, math.Min(param.Max, w[i]))
+		}
+
+		if texel.OnIteration != nil {
+			texel.OnIteration(iteration, stats(features, data, w, fit.K).Loss)
+		}
+	}
+
+	// Weights are whole numbers
+	fit.Weights = start.Copy()
+	for i, param := range params {
+		w[i] = math.Round(w[i])
+		for _, pattern := range param.Patterns {
+			fit.Weights.Config[pattern] = int(w[i])
+		}
+	}
+	fit.After = stats(features, data, w, fit.K)
+	return fit
+}
+
+// Evaluation of the board for X is linear in the pattern weights, so each
+// param's feature is the evaluation with that param weighted 1 and the rest
+// 0
+func patternFeatures(board *game.Board, params []Param) []float64 {
+	features := make([]float64, len(params))
+	for i, param := range params {
+		config := map[string]int{"TTTT": game.ConfigValues["TTTT"]}
+		for _, pattern := range param.Patterns {
+			config[pattern] = 1
+		}
+		features[i] = float64(board.CalcPlayerValueWith(game.Tokens[0], config))
+	}
+	return features
+}
+
+// Finds the scale that best fits the weights as they are, searching
+// powers of two then narrowing in
+func fitK(features [][]float64, data []Sample, w []float64) float64 {
+	best, bestLoss := 1.0, math.Inf(1)
+	for k := 1.0 / 1024; k <= 16; k *= 2 {
+		if loss := stats(features, data, w, k).Loss; loss < bestLoss {
+			best, bestLoss = k, loss
+		}
+	}
+
+	lo, hi := best/2, best*2
+	for i := 0; i < 30; i++ {
+		a, b := lo+(hi-lo)/3, hi-(hi-lo)/3
+		if stats(features, data, w, a).Loss < stats(features, data, w, b).Loss {
+			hi = b
+		} else {
+			lo = a
+		}
+	}
+	return (lo + hi) / 2
+}
+
+func stats(features [][]float64, data []Sample, w []float64, k float64) Stats {
+	if len(data) == 0 {
+		return Stats{}
+	}
+
+	var s Stats
+	decided, correct := 0, 0
+	for j, f := range features {
+		value := dot(w, f)
+
+		// Keep clear of log(0)
+		p := math.Max(1e-12, math.Min(1-1e-12, sigmoid(k*value)))
+		y := data[j].Result
+		s.Loss -= y*math.Log(p) + (1-y)*math.Log(1-p)
+
+		if y != 0.5 {
+			decided++
+			if (y == 1 && value > 0) || (y == 0 && value < 0) {
+				correct++
+			}
+		}
+	}
+
+	s.Loss /= float64(len(data))
+	if decided > 0 {
+		s.Accuracy = float64(correct) / float64(decided)
+	}
+	return s
+}
+
+func dot(a []float64, b []float64) float64 {
+	sum := 0.0
+	for i := range a {
+		sum += a[i] * b[i]
+	}
+	return sum
+}
+
+func sigmoid(x float64) float64 {
+	return 1 / (1 + math.Exp(-x))
+}
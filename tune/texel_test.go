@@ -0,0 +1,110 @@
+package tune
+
+import (
+	"FinalProject/game"
+	"math"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+// Positions from random games, stopped before anyone wins
+func randomBoards(n int, rng *rand.Rand) []*game.Board {
+	var boards []*game.Board
+	for len(boards) < n {
+		moves := randomOpening(6+rng.Intn(20), rng)
+		board := game.NewBoard()
+		board.PlayMoves(moves)
+		boards = append(boards, board)
+	}
+	return boards
+}
+
+func TestFeaturesAreLinear(t *testing.T) {
+	params := DefaultParams()[:6]
+	weights := game.DefaultWeights()
+	w := make([]float64, len(params))
+	for i, param := range params {
+		w[i] = float64(weights.Config[param.Patterns[0]])
+	}
+
+	for _, board := range randomBoards(50, rand.New(rand.NewSource(1))) {
+		if got, want := dot(w, patternFeatures(board, params)), board.CalcPlayerValue(game.Tokens[0]); int(got) != want {
+			t.Fatalf("Features give %v, evaluation gives %d for %s", got, want, board.Encode())
+		}
+	}
+}
+
+func TestLoadSamples(t *testing.T) {
+	text := `# labeled
+......./......./......./......./......./...X... O X
+......./......./......./......./......./...XO.. X draw
+`
+	samples, err := LoadSamples(strings.NewReader(text))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(samples) != 2 || samples[0].Result != 1 || samples[1].Result != 0.5 {
+		t.Errorf("Unexpected samples %+v", samples)
+	}
+
+	if _, err := LoadSamples(strings.NewReader("......./......./......./......./......./....... X maybe")); err == nil {
+		t.Error("Should reject a bad result")
+	}
+}
+
+func TestSamplesFromRecord(t *testing.T) {
+	record := game.NewRecord("a", "b", 0)
+	for _, col := range []int{0, 1, 0, 1, 0, 1, 0} {
+		record.AddMove(col, nil, "")
+	}
+
+	if samples := SamplesFromRecord(record, 0); samples != nil {
+		t.Error("Unfinished games shouldn't give samples")
+	}
+
+	record.Result = "O"
+	samples := SamplesFromRecord(record, 2)
+	if len(samples) != 5 || samples[0].Result != 0 || samples[0].Board.Height(0) != 1 {
+		t.Errorf("Expected the positions after moves 2 to 6 labeled as O wins, got %d", len(samples))
+	}
+}
+
+func TestFit(t *testing.T) {
+	// Results decided by weights that only care about three in a row
+	target := game.DefaultWeights()
+	for _, pattern := range game.WeightPatterns() {
+		target.Config[pattern] = 0
+	}
+	target.Config[" TTT "] = 20
+	target.Config["TTT "] = 10
+	target.Config[" TTT"] = 10
+
+	var samples []Sample
+	for _, board := range randomBoards(300, rand.New(rand.NewSource(2))) {
+		value := board.CalcPlayerValueWith(game.Tokens[0], target.Config)
+		result := 0.5
+		if value > 0 {
+			result = 1
+		} else if value < 0 {
+			result = 0
+		}
+		samples = append(samples, Sample{Board: board, Result: result})
+	}
+
+	texel := NewTexel()
+	texel.Iterations = 50
+	fit := texel.Fit(game.DefaultWeights(), samples)
+
+	if fit.After.Loss >= fit.Before.Loss || fit.After.Accuracy < fit.Before.Accuracy {
+		t.Errorf("Fit should improve on the start: before %+v, after %+v", fit.Before, fit.After)
+	}
+
+	if math.IsNaN(fit.K) || fit.K <= 0 {
+		t.Errorf("Bad scale %v", fit.K)
+	}
+
+	if fit.Weights.Decay != game.Decay || fit.Weights.Config["T "] != fit.Weights.Config[" T"] {
+		t.Error("Decay should be untouched and mirror patterns kept equal")
+	}
+}
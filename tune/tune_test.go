@@ -0,0 +1,74 @@
+package tune
+
+import (
+	"FinalProject/game"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestVectorRoundTrip(t *testing.T) {
+	params := DefaultParams()
+	weights := game.DefaultWeights()
+
+	again := fromVector(weights, params, toVector(weights, params))
+	for pattern, value := range weights.Config {
+		if again.Config[pattern] != value {
+			t.Errorf("Pattern %q changed from %d to %d", pattern, value, again.Config[pattern])
+		}
+	}
+	if math.Abs(again.Decay-weights.Decay) > 1e-9 {
+		t.Errorf("Decay changed to %v", again.Decay)
+	}
+}
+
+func TestFromVectorBounds(t *testing.T) {
+	params := DefaultParams()
+	theta := make([]float64, len(params))
+	for i := range theta {
+		theta[i] = -100
+	}
+
+	weights := fromVector(game.DefaultWeights(), params, theta)
+	if weights.Config["T "] != 0 || weights.Config[" T"] != 0 || weights.Decay != 0.5 {
+		t.Errorf("Params should be kept at their minimum: %v %v", weights.Config, weights.Decay)
+	}
+}
+
+func TestCompare(t *testing.T) {
+	// Without pattern weights a player only sees wins
+	blind := game.DefaultWeights()
+	for _, pattern := range game.WeightPatterns() {
+		blind.Config[pattern] = 0
+	}
+
+	score := Compare(game.DefaultWeights(), blind, 2, 4, 2, rand.New(rand.NewSource(1)))
+	if score <= 0 {
+		t.Errorf("Default weights should beat blind weights, scored %v", score)
+	}
+}
+
+func TestSPSA(t *testing.T) {
+	spsa := NewSPSA()
+	spsa.Iterations = 3
+	spsa.Pairs = 1
+	spsa.Depth = 1
+	spsa.Rand = rand.New(rand.NewSource(1))
+
+	iterations := 0
+	spsa.OnIteration = func(iteration int, weights *game.Weights, score float64) {
+		iterations++
+		if score < -1 || score > 1 {
+			t.Errorf("Score %v out of range", score)
+		}
+	}
+
+	weights := spsa.Tune(game.DefaultWeights())
+	if iterations != 3 {
+		t.Errorf("Expected 3 iterations, got %d", iterations)
+	}
+
+	if weights.Config["TTTT"] != game.ConfigValues["TTTT"] || weights.Config["T "] != weights.Config[" T"] {
+		t.Errorf("Win weight should be untouched and mirrors kept equal: %v", weights.Config)
+	}
+}
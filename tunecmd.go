@@ -0,0 +1,73 @@
+package main
+
+import (
+	"FinalProject/game"
+	"FinalProject/tune"
+	"flag"
+	"fmt"
+	"math/rand"
+	"os"
+	"time"
+)
+
+// Tunes the evaluation weights by self-play and writes them to a file that
+// can be loaded with -weights
+func tuneCommand(args []string) {
+	flags := flag.NewFlagSet("tune", flag.ExitOnError)
+	iterations := flags.Int("iterations", 100, "SPSA iterations")
+	pairs := flags.Int("pairs", 8, "pairs of games per iteration")
+	depth := flags.Int("depth", 2, "search depth in games")
+	rate := flags.Float64("rate", 2, "learning rate")
+	start := flags.String("start", "", "weights file to start from (default the built in weights)")
+	out := flags.String("out", "weights.txt", "file to write")
+	check := flags.Int("check", 50, "pairs of games between the result and the start weights (0 to skip)")
+	flags.Parse(args)
+
+	initial := game.DefaultWeights()
+	if *start != "" {
+		var err error
+		initial, err = loadWeights(*start)
+		exitIf(err)
+	}
+
+	spsa := tune.NewSPSA()
+	spsa.Iterations = *iterations
+	spsa.Pairs = *pairs
+	spsa.Depth = *depth
+	spsa.LearningRate = *rate
+	spsa.OnIteration = func(iteration int, weights *game.Weights, score float64) {
+		fmt.Printf("%4d  score %+.2f  decay %.3f ", iteration, score, weights.Decay)
+		for _, pattern := range game.WeightPatterns() {
+			fmt.Printf(" %q:%d", pattern, weights.Config[pattern])
+		}
+		fmt.Println()
+	}
+
+	began := time.Now()
+	tuned := spsa.Tune(initial)
+	fmt.Printf("Tuned in %v\n", time.Since(began).Round(time.Second))
+
+	f, err := os.Create(*out)
+	exitIf(err)
+	if err := game.SaveWeights(f, tuned); err != nil {
+		f.Close()
+		exitIf(err)
+	}
+	exitIf(f.Close())
+	fmt.Printf("Wrote %s\n", *out)
+
+	if *check > 0 {
+		rng := rand.New(rand.NewSource(time.Now().UnixNano()))
+		score := tune.Compare(tuned, initial, *depth, *check, spsa.OpeningPlies, rng)
+		fmt.Printf("Tuned weights scored %+.2f against the start over %d games\n", score, 2**check)
+	}
+}
+
+func loadWeights(path string) (*game.Weights, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return game.LoadWeights(f)
+}
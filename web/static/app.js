@@ -0,0 +1,171 @@
+// Plays against the engine through the JSON API served next to this page.
+"use strict";
+
+const COLS = 7;
+const ROWS = 6;
+
+const boardEl = document.getElementById("board");
+const statusEl = document.getElementById("status");
+const analysisEl = document.getElementById("analysis");
+const evalFill = document.getElementById("evalfill");
+const depthEl = document.getElementById("depth");
+const sideEl = document.getElementById("side");
+const hintsEl = document.getElementById("hints");
+
+let game = null;
+let human = "X";
+let busy = false;
+let hint = 0;
+
+async function api(method, path, body) {
+  const res = await fetch(path, {
+    method: method,
+    headers: { "Content-Type": "application/json" },
+    body: body === undefined ? undefined : JSON.stringify(body),
+  });
+  const data = await res.json();
+  if (!res.ok) {
+    throw new Error(data.error || res.statusText);
+  }
+  return data;
+}
+
+function depth() {
+  return parseInt(depthEl.value, 10);
+}
+
+// Row (from the top) of the topmost token in a column, or -1
+function topRow(col) {
+  for (let r = 0; r < ROWS; r++) {
+    if (game.rows[r][col] !== ".") {
+      return r;
+    }
+  }
+  return -1;
+}
+
+function render() {
+  const last = game.moves.length ? parseInt(game.moves[game.moves.length - 1], 10) - 1 : -1;
+  const lastRow = last >= 0 ? topRow(last) : -1;
+
+  boardEl.replaceChildren();
+  boardEl.className = busy ? "waiting" : "";
+  if (hint > 0) {
+    boardEl.classList.add("col-hint-" + hint);
+  }
+
+  for (let r = 0; r < ROWS; r++) {
+    for (let c = 0; c < COLS; c++) {
+      const cell = document.createElement("div");
+      const token = game.rows[r][c];
+      cell.className = "cell";
+      if (token !== ".") {
+        cell.classList.add(token);
+      }
+      if (r === lastRow && c === last) {
+        cell.classList.add("last");
+      }
+      cell.setAttribute("role", "gridcell");
+      cell.addEventListener("click", () => play(c + 1));
+      boardEl.appendChild(cell);
+    }
+  }
+
+  if (game.over) {
+    statusEl.textContent = game.winner ? (game.winner === human ? "You win!" : "The engine wins.") : "Tie game.";
+  } else if (busy) {
+    statusEl.textContent = "Engine is thinking...";
+  } else {
+    statusEl.textContent = "Your move: click a column.";
+  }
+}
+
+// Fills the bar from X's point of view. value is for the side to move.
+function showEval(value, turn) {
+  const forX = turn === "X" ? value : -value;
+  const frac = 0.5 + 0.5 * forX / (Math.abs(forX) + 50);
+  evalFill.style.height = (100 * frac).toFixed(1) + "%";
+}
+
+async function analyze() {
+  hint = 0;
+  analysisEl.textContent = "";
+  if (game.over) {
+    const x = game.winner === "X" ? 1 : game.winner === "O" ? -1 : 0;
+    showEval(x, "X");
+    return;
+  }
+
+  const result = await api("POST", "/analyze", { moves: game.moves, depth: depth() });
+  showEval(result.value, game.turn);
+  if (hintsEl.checked && game.turn === human) {
+    hint = result.move;
+    analysisEl.textContent = `Suggested: column ${result.move} (value ${result.value}, line ${result.line})`;
+  } else {
+    analysisEl.textContent = `Evaluation for ${game.turn}: ${result.value}`;
+  }
+}
+
+async function engineMove() {
+  busy = true;
+  render();
+  try {
+    const result = await api("POST", `/games/${game.id}/engine`, { depth: depth() });
+    game = result.game;
+  } finally {
+    busy = false;
+  }
+  render();
+  await analyze();
+  render();
+}
+
+async function play(col) {
+  if (busy || !game || game.over || game.turn !== human || !game.validMoves.includes(col)) {
+    return;
+  }
+
+  try {
+    game = await api("POST", `/games/${game.id}/moves`, { column: col });
+    render();
+    if (!game.over) {
+      await engineMove();
+    } else {
+      await analyze();
+      render();
+    }
+  } catch (err) {
+    statusEl.textContent = err.message;
+  }
+}
+
+async function newGame() {
+  human = sideEl.value;
+  busy = false;
+  try {
+    game = await api("POST", "/games", { first: "x" });
+    render();
+    if (human !== game.turn) {
+      await engineMove();
+    } else {
+      await analyze();
+      render();
+    }
+  } catch (err) {
+    statusEl.textContent = err.message;
+  }
+}
+
+document.getElementById("settings").addEventListener("submit", (e) => {
+  e.preventDefault();
+  newGame();
+});
+
+hintsEl.addEventListener("change", async () => {
+  if (game) {
+    await analyze();
+    render();
+  }
+});
+
+newGame();
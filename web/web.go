@@ -0,0 +1,23 @@
+// Package web embeds a single page that plays against the engine in a
+// browser. It only talks to the JSON API from the api package, so it works
+// from localhost without any network access.
+package web
+
+import (
+	"embed"
+	"net/http"
+)
+
+//go:embed static
+var static embed.FS
+
+// Adds the page at / and its files under /static/ to mux. Every other path
+// is left to whatever else is registered, such as the API.
+func Register(mux *http.ServeMux) {
+	files := http.FileServer(http.FS(static))
+
+	mux.Handle("GET /static/", files)
+	mux.HandleFunc("GET /{$}", func(w http.ResponseWriter, r *http.Request) {
+		http.ServeFileFS(w, r, static, "static/index.html")
+	})
+}
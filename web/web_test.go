@@ -0,0 +1,43 @@
+package web
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestServesPageAndAssets(t *testing.T) {
+	mux := http.NewServeMux()
+	Register(mux)
+
+	for path, want := range map[string]string{
+		"/":                 "/static/app.js",
+		"/static/app.js":    "/analyze",
+		"/static/style.css": "#board",
+	} {
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest("GET", path, nil))
+
+		if rec.Code != http.StatusOK {
+			t.Errorf("GET %s returned %d", path, rec.Code)
+			continue
+		}
+
+		if !strings.Contains(rec.Body.String(), want) {
+			t.Errorf("GET %s should contain %q", path, want)
+		}
+	}
+}
+
+func TestLeavesOtherPathsAlone(t *testing.T) {
+	mux := http.NewServeMux()
+	Register(mux)
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest("GET", "/games/1", nil))
+
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("Paths outside the page should not be handled, got %d", rec.Code)
+	}
+}